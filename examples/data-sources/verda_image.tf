@@ -0,0 +1,14 @@
+# Most recent Ubuntu image with CUDA
+data "verda_image" "ubuntu_cuda" {
+  name_regex  = "(?i)ubuntu.*cuda"
+  most_recent = true
+}
+
+# Use the image for an instance
+resource "verda_instance" "example" {
+  instance_type = "1B200.30V"
+  image         = data.verda_image.ubuntu_cuda.image_type
+  hostname      = "example-instance"
+  description   = "Example instance"
+  location      = "FIN-03"
+}
@@ -0,0 +1,9 @@
+# All images with Ubuntu in their name
+data "verda_images" "ubuntu" {
+  name_regex = "(?i)ubuntu"
+}
+
+# Output matching image types
+output "ubuntu_images" {
+  value = data.verda_images.ubuntu.images[*].image_type
+}
@@ -0,0 +1,13 @@
+# On-demand availability of a single instance type in all locations
+data "verda_instance_availability" "b200" {
+  instance_type = "1B200.30V"
+  is_spot       = false
+}
+
+# Output locations where the instance type can be provisioned right now
+output "b200_locations" {
+  value = [
+    for availability in data.verda_instance_availability.b200.availabilities : availability.location
+    if availability.available
+  ]
+}
@@ -0,0 +1,10 @@
+# Instance types with at least one B200 GPU
+data "verda_instance_types" "b200" {
+  gpu_model     = "B200"
+  min_gpu_count = 1
+}
+
+# Output matching instance type names
+output "b200_instance_types" {
+  value = data.verda_instance_types.b200.instance_types[*].instance_type
+}
@@ -0,0 +1,15 @@
+# All Verda locations
+data "verda_locations" "all" {}
+
+# Output location codes
+output "location_codes" {
+  value = data.verda_locations.all.locations[*].code
+}
+
+# Locations where a specific instance type is currently available
+output "b200_locations" {
+  value = [
+    for location in data.verda_locations.all.locations : location.code
+    if contains(location.available_instance_types, "1B200.30V")
+  ]
+}
@@ -46,3 +46,26 @@ resource "verda_container_registry_credentials" "docker_config" {
   type               = "dockerhub"
   docker_config_json = file("${path.module}/.docker/config.json")
 }
+
+# Write-only token that is never stored in the state (Terraform 1.11+).
+# Bump credentials_version to apply a new token.
+resource "verda_container_registry_credentials" "ghcr_write_only" {
+  name                = "github-registry-wo"
+  type                = "ghcr"
+  username            = "myghusername"
+  access_token_wo     = var.ghcr_token
+  credentials_version = 1
+}
+
+# Credentials with a generated name, rotated without downtime: the new
+# credentials are created before the old ones are deleted.
+resource "verda_container_registry_credentials" "ghcr_rotating" {
+  name_prefix  = "github-registry-"
+  type         = "ghcr"
+  username     = "myghusername"
+  access_token = var.ghcr_token
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
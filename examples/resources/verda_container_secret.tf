@@ -0,0 +1,65 @@
+# Secret referenced by an environment variable
+resource "verda_container_secret" "api_key" {
+  name  = "api-key"
+  value = var.api_key
+}
+
+# File secret mounted as a volume
+resource "verda_container_file_secret" "config" {
+  name = "app-config"
+  files = {
+    "config.json" = file("${path.module}/config.json")
+    "ca.pem"      = file("${path.module}/ca.pem")
+  }
+}
+
+resource "verda_container" "app" {
+  name = "my-app"
+
+  compute = {
+    name = "RTX 4500 Ada"
+    size = 1
+  }
+
+  scaling = {
+    min_replica_count               = 1
+    max_replica_count               = 2
+    queue_message_ttl_seconds       = 300
+    concurrent_requests_per_replica = 1
+
+    scale_down_policy = {
+      delay_seconds = 300
+    }
+
+    scale_up_policy = {
+      delay_seconds = 0
+    }
+
+    queue_load = {
+      threshold = 1
+    }
+  }
+
+  containers = [
+    {
+      image        = "myorg/app:1.0.0"
+      exposed_port = 8080
+
+      env = [
+        {
+          type                         = "secret"
+          name                         = "API_KEY"
+          value_or_reference_to_secret = verda_container_secret.api_key.name
+        }
+      ]
+
+      volume_mounts = [
+        {
+          type        = "secret"
+          mount_path  = "/etc/app"
+          secret_name = verda_container_file_secret.config.name
+        }
+      ]
+    }
+  ]
+}
@@ -22,6 +22,28 @@ resource "verda_instance" "with_volume" {
   existing_volumes = [verda_volume.data.id]
 }
 
+# Instance created in the first location with capacity
+resource "verda_instance" "fallback" {
+  instance_type = "1B200.30V"
+  image         = "ubuntu-24.04-cuda-12.8-open-docker"
+  hostname      = "fallback-instance"
+  description   = "Instance with location fallback"
+
+  location_preferences = ["FIN-03", "FIN-01"]
+  wait_for_capacity    = "30m"
+  capacity_check       = "warn"
+}
+
+# Instance that can be powered down without losing its OS volume
+resource "verda_instance" "workstation" {
+  instance_type  = "1B200.30V"
+  image          = "ubuntu-24.04-cuda-12.8-open-docker"
+  hostname       = "workstation"
+  description    = "Workstation that is shut down overnight"
+  location       = "FIN-03"
+  desired_status = "shutdown"
+}
+
 # Output instance information
 output "instance_ip" {
   value = verda_instance.example.ip
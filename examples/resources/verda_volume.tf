@@ -14,3 +14,12 @@ output "volume_id" {
 output "volume_status" {
   value = verda_volume.data.status
 }
+
+# Clone an existing volume, e.g. to seed a new instance from a golden dataset
+resource "verda_volume" "data_copy" {
+  name             = "my-data-volume-copy"
+  size             = 100 # GB, must be at least the size of the source
+  type             = "NVMe"
+  location         = "FIN-01"
+  source_volume_id = verda_volume.data.id
+}
@@ -0,0 +1,27 @@
+# Attach a data volume to an instance without recreating the instance.
+# Volumes can only be attached to or detached from shut down instances.
+resource "verda_instance" "trainer" {
+  instance_type  = "1V100.6V"
+  image          = "ubuntu-24.04-cuda-12.8-open-docker"
+  hostname       = "trainer"
+  description    = "Model training instance"
+  location       = "FIN-01"
+  desired_status = "shutdown"
+}
+
+resource "verda_volume" "dataset" {
+  name     = "dataset"
+  size     = 500 # GB
+  type     = "NVMe"
+  location = "FIN-01"
+}
+
+resource "verda_volume_attachment" "dataset" {
+  volume_id   = verda_volume.dataset.id
+  instance_id = verda_instance.trainer.id
+}
+
+# Output attachment information
+output "attachment_status" {
+  value = verda_volume_attachment.dataset.status
+}
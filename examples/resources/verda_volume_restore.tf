@@ -0,0 +1,14 @@
+# Restore a volume that was deleted by mistake from the trash.
+# Import it into a verda_volume resource afterwards to manage it again.
+resource "verda_volume_restore" "data" {
+  volume_id = "<deleted-volume-id>"
+}
+
+# Output restored volume information
+output "restored_volume_name" {
+  value = verda_volume_restore.data.name
+}
+
+output "restored_volume_status" {
+  value = verda_volume_restore.data.status
+}
@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda"
+)
+
+var _ datasource.DataSource = &ImageDataSource{}
+
+func NewImageDataSource() datasource.DataSource {
+	return &ImageDataSource{}
+}
+
+type ImageDataSource struct {
+	client *verda.Client
+}
+
+type ImageDataSourceModel struct {
+	NameRegex  types.String `tfsdk:"name_regex"`
+	MostRecent types.Bool   `tfsdk:"most_recent"`
+	ID         types.String `tfsdk:"id"`
+	ImageType  types.String `tfsdk:"image_type"`
+	Name       types.String `tfsdk:"name"`
+	Category   types.String `tfsdk:"category"`
+	IsDefault  types.Bool   `tfsdk:"is_default"`
+	Details    types.List   `tfsdk:"details"`
+}
+
+func (d *ImageDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_image"
+}
+
+func (d *ImageDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a single OS image available for Verda instances. The images API does not report which instance types an image supports, " +
+			"so compatible instance types are not exposed.",
+
+		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Regular expression the image name must match",
+				Optional:            true,
+			},
+			"most_recent": schema.BoolAttribute{
+				MarkdownDescription: "If more than one image matches, use the one with the highest version in its image type instead of failing (defaults to false)",
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Image identifier",
+				Computed:            true,
+			},
+			"image_type": schema.StringAttribute{
+				MarkdownDescription: "Image type to use in `verda_instance.image` (e.g., 'ubuntu-24.04-cuda-12.8-open-docker')",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Display name of the image",
+				Computed:            true,
+			},
+			"category": schema.StringAttribute{
+				MarkdownDescription: "Category of the image. When set, only images in this category are considered.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"is_default": schema.BoolAttribute{
+				MarkdownDescription: "Whether this is the default image",
+				Computed:            true,
+			},
+			"details": schema.ListAttribute{
+				MarkdownDescription: "Additional details about the image (e.g., installed software)",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ImageDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*verda.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *verda.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ImageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ImageDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	images, err := d.client.Images.Get(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read images, got error: %s", err))
+		return
+	}
+
+	matches, err := filterImages(images, data.NameRegex, data.Category)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Name Regex", fmt.Sprintf("Unable to compile name_regex, got error: %s", err))
+		return
+	}
+
+	if len(matches) == 0 {
+		resp.Diagnostics.AddError(
+			"No Matching Image",
+			"No image matched the given criteria. Use the verda_images data source to list the available images.",
+		)
+		return
+	}
+
+	if len(matches) > 1 {
+		if !data.MostRecent.ValueBool() {
+			resp.Diagnostics.AddError(
+				"Multiple Matching Images",
+				fmt.Sprintf("%d images matched the given criteria. Narrow down name_regex or category, or set most_recent = true.", len(matches)),
+			)
+			return
+		}
+		sortImagesByVersion(matches)
+	}
+
+	image := flattenImageToModel(ctx, matches[0], &resp.Diagnostics)
+	data.ID = image.ID
+	data.ImageType = image.ImageType
+	data.Name = image.Name
+	data.Category = image.Category
+	data.IsDefault = image.IsDefault
+	data.Details = image.Details
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,261 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda"
+)
+
+var _ datasource.DataSource = &ImagesDataSource{}
+
+func NewImagesDataSource() datasource.DataSource {
+	return &ImagesDataSource{}
+}
+
+type ImagesDataSource struct {
+	client *verda.Client
+}
+
+type ImagesDataSourceModel struct {
+	NameRegex types.String `tfsdk:"name_regex"`
+	Category  types.String `tfsdk:"category"`
+	Images    []ImageModel `tfsdk:"images"`
+}
+
+type ImageModel struct {
+	ID        types.String `tfsdk:"id"`
+	ImageType types.String `tfsdk:"image_type"`
+	Name      types.String `tfsdk:"name"`
+	Category  types.String `tfsdk:"category"`
+	IsDefault types.Bool   `tfsdk:"is_default"`
+	Details   types.List   `tfsdk:"details"`
+}
+
+func (d *ImagesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_images"
+}
+
+func (d *ImagesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the OS images available for Verda instances. The images API does not report which instance types an image supports, " +
+			"so compatible instance types are not exposed.",
+
+		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Only return images whose name matches this regular expression",
+				Optional:            true,
+			},
+			"category": schema.StringAttribute{
+				MarkdownDescription: "Only return images in this category",
+				Optional:            true,
+			},
+			"images": schema.ListNestedAttribute{
+				MarkdownDescription: "Images matching the filters",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: imageDataSourceAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// imageDataSourceAttributes returns the computed attributes describing a single image
+func imageDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: "Image identifier",
+			Computed:            true,
+		},
+		"image_type": schema.StringAttribute{
+			MarkdownDescription: "Image type to use in `verda_instance.image` (e.g., 'ubuntu-24.04-cuda-12.8-open-docker')",
+			Computed:            true,
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: "Display name of the image",
+			Computed:            true,
+		},
+		"category": schema.StringAttribute{
+			MarkdownDescription: "Category of the image",
+			Computed:            true,
+		},
+		"is_default": schema.BoolAttribute{
+			MarkdownDescription: "Whether this is the default image",
+			Computed:            true,
+		},
+		"details": schema.ListAttribute{
+			MarkdownDescription: "Additional details about the image (e.g., installed software)",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
+	}
+}
+
+func (d *ImagesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*verda.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *verda.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ImagesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ImagesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	images, err := d.client.Images.Get(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read images, got error: %s", err))
+		return
+	}
+
+	matches, err := filterImages(images, data.NameRegex, data.Category)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Name Regex", fmt.Sprintf("Unable to compile name_regex, got error: %s", err))
+		return
+	}
+
+	data.Images = []ImageModel{}
+	for _, image := range matches {
+		data.Images = append(data.Images, flattenImageToModel(ctx, image, &resp.Diagnostics))
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// filterImages returns the images matching the optional name regex and category
+func filterImages(images []verda.Image, nameRegex types.String, category types.String) ([]verda.Image, error) {
+	var re *regexp.Regexp
+	if !nameRegex.IsNull() && nameRegex.ValueString() != "" {
+		var err error
+		re, err = regexp.Compile(nameRegex.ValueString())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var matches []verda.Image
+	for _, image := range images {
+		if re != nil && !re.MatchString(image.Name) {
+			continue
+		}
+
+		if !category.IsNull() && category.ValueString() != "" && image.Category != category.ValueString() {
+			continue
+		}
+
+		matches = append(matches, image)
+	}
+
+	return matches, nil
+}
+
+// sortImagesByVersion sorts images so that the most recent one comes first.
+// The images API has no timestamps, so recency is derived from the version
+// numbers embedded in the image type (e.g., 'ubuntu-24.04-cuda-12.8').
+func sortImagesByVersion(images []verda.Image) {
+	sort.SliceStable(images, func(i, j int) bool {
+		return compareVersionStrings(images[i].ImageType, images[j].ImageType) > 0
+	})
+}
+
+// compareVersionStrings compares two strings, treating runs of digits as numbers
+func compareVersionStrings(a, b string) int {
+	aParts := splitVersionString(a)
+	bParts := splitVersionString(b)
+
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		aNum, aErr := strconv.Atoi(aParts[i])
+		bNum, bErr := strconv.Atoi(bParts[i])
+
+		switch {
+		case aErr == nil && bErr == nil:
+			if aNum != bNum {
+				if aNum > bNum {
+					return 1
+				}
+				return -1
+			}
+		case aParts[i] != bParts[i]:
+			if aParts[i] > bParts[i] {
+				return 1
+			}
+			return -1
+		}
+	}
+
+	switch {
+	case len(aParts) > len(bParts):
+		return 1
+	case len(aParts) < len(bParts):
+		return -1
+	default:
+		return 0
+	}
+}
+
+// splitVersionString splits a string into alternating digit and non-digit runs
+func splitVersionString(s string) []string {
+	var parts []string
+	var current []rune
+	var currentIsDigit bool
+
+	for i, r := range s {
+		isDigit := unicode.IsDigit(r)
+		if i > 0 && isDigit != currentIsDigit {
+			parts = append(parts, string(current))
+			current = nil
+		}
+		current = append(current, r)
+		currentIsDigit = isDigit
+	}
+
+	if len(current) > 0 {
+		parts = append(parts, string(current))
+	}
+
+	return parts
+}
+
+func flattenImageToModel(ctx context.Context, image verda.Image, diagnostics *diag.Diagnostics) ImageModel {
+	details, diags := types.ListValueFrom(ctx, types.StringType, image.Details)
+	diagnostics.Append(diags...)
+
+	return ImageModel{
+		ID:        types.StringValue(image.ID),
+		ImageType: types.StringValue(image.ImageType),
+		Name:      types.StringValue(image.Name),
+		Category:  types.StringValue(image.Category),
+		IsDefault: types.BoolValue(image.IsDefault),
+		Details:   details,
+	}
+}
@@ -0,0 +1,199 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda"
+)
+
+var _ datasource.DataSource = &InstanceAvailabilityDataSource{}
+
+func NewInstanceAvailabilityDataSource() datasource.DataSource {
+	return &InstanceAvailabilityDataSource{}
+}
+
+type InstanceAvailabilityDataSource struct {
+	client *verda.Client
+}
+
+type InstanceAvailabilityDataSourceModel struct {
+	InstanceType   types.String                `tfsdk:"instance_type"`
+	Location       types.String                `tfsdk:"location"`
+	IsSpot         types.Bool                  `tfsdk:"is_spot"`
+	Available      types.Bool                  `tfsdk:"available"`
+	Availabilities []InstanceAvailabilityModel `tfsdk:"availabilities"`
+}
+
+type InstanceAvailabilityModel struct {
+	InstanceType types.String `tfsdk:"instance_type"`
+	Location     types.String `tfsdk:"location"`
+	IsSpot       types.Bool   `tfsdk:"is_spot"`
+	Available    types.Bool   `tfsdk:"available"`
+}
+
+func (d *InstanceAvailabilityDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_instance_availability"
+}
+
+func (d *InstanceAvailabilityDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reports whether Verda instance types can currently be provisioned in each location",
+
+		Attributes: map[string]schema.Attribute{
+			"instance_type": schema.StringAttribute{
+				MarkdownDescription: "Only report this instance type (e.g., '1B200.30V')",
+				Optional:            true,
+			},
+			"location": schema.StringAttribute{
+				MarkdownDescription: "Only report this location (e.g., 'FIN-01')",
+				Optional:            true,
+			},
+			"is_spot": schema.BoolAttribute{
+				MarkdownDescription: "Only report spot (true) or on-demand (false) capacity. Both are reported when unset.",
+				Optional:            true,
+			},
+			"available": schema.BoolAttribute{
+				MarkdownDescription: "Whether at least one of the reported combinations is available",
+				Computed:            true,
+			},
+			"availabilities": schema.ListNestedAttribute{
+				MarkdownDescription: "Availability of each instance type, location and spot combination matching the filters",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"instance_type": schema.StringAttribute{
+							MarkdownDescription: "Instance type",
+							Computed:            true,
+						},
+						"location": schema.StringAttribute{
+							MarkdownDescription: "Location code",
+							Computed:            true,
+						},
+						"is_spot": schema.BoolAttribute{
+							MarkdownDescription: "Whether this is spot capacity",
+							Computed:            true,
+						},
+						"available": schema.BoolAttribute{
+							MarkdownDescription: "Whether the instance type can currently be provisioned",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *InstanceAvailabilityDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*verda.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *verda.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *InstanceAvailabilityDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data InstanceAvailabilityDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var instanceTypeNames []string
+	if !data.InstanceType.IsNull() && data.InstanceType.ValueString() != "" {
+		instanceTypeNames = []string{data.InstanceType.ValueString()}
+	} else {
+		instanceTypes, err := d.client.InstanceTypes.Get(ctx, "")
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read instance types, got error: %s", err))
+			return
+		}
+		for _, instanceType := range instanceTypes {
+			instanceTypeNames = append(instanceTypeNames, instanceType.InstanceType)
+		}
+	}
+
+	var locationCodes []string
+	if !data.Location.IsNull() && data.Location.ValueString() != "" {
+		locationCodes = []string{data.Location.ValueString()}
+	} else {
+		locations, err := d.client.Locations.Get(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read locations, got error: %s", err))
+			return
+		}
+		for _, location := range locations {
+			locationCodes = append(locationCodes, location.Code)
+		}
+	}
+
+	spotValues := []bool{false, true}
+	if !data.IsSpot.IsNull() {
+		spotValues = []bool{data.IsSpot.ValueBool()}
+	}
+
+	data.Available = types.BoolValue(false)
+	data.Availabilities = []InstanceAvailabilityModel{}
+	for _, isSpot := range spotValues {
+		available, err := d.availableInstanceTypes(ctx, isSpot, data.Location.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read instance availability, got error: %s", err))
+			return
+		}
+
+		for _, locationCode := range locationCodes {
+			for _, instanceType := range instanceTypeNames {
+				isAvailable := available[locationCode][instanceType]
+				if isAvailable {
+					data.Available = types.BoolValue(true)
+				}
+
+				data.Availabilities = append(data.Availabilities, InstanceAvailabilityModel{
+					InstanceType: types.StringValue(instanceType),
+					Location:     types.StringValue(locationCode),
+					IsSpot:       types.BoolValue(isSpot),
+					Available:    types.BoolValue(isAvailable),
+				})
+			}
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// availableInstanceTypes returns the available instance types keyed by location code and instance type
+func (d *InstanceAvailabilityDataSource) availableInstanceTypes(ctx context.Context, isSpot bool, locationCode string) (map[string]map[string]bool, error) {
+	availabilities, err := d.client.InstanceAvailability.GetAllAvailabilities(ctx, isSpot, locationCode)
+	if err != nil {
+		return nil, err
+	}
+
+	available := make(map[string]map[string]bool)
+	for _, availability := range availabilities {
+		if available[availability.LocationCode] == nil {
+			available[availability.LocationCode] = make(map[string]bool)
+		}
+		for _, instanceType := range availability.Availabilities {
+			available[availability.LocationCode][instanceType] = true
+		}
+	}
+
+	return available, nil
+}
@@ -0,0 +1,307 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda"
+)
+
+var _ datasource.DataSource = &InstanceTypesDataSource{}
+
+func NewInstanceTypesDataSource() datasource.DataSource {
+	return &InstanceTypesDataSource{}
+}
+
+type InstanceTypesDataSource struct {
+	client *verda.Client
+}
+
+type InstanceTypesDataSourceModel struct {
+	GPUModel        types.String        `tfsdk:"gpu_model"`
+	MinGPUCount     types.Int64         `tfsdk:"min_gpu_count"`
+	MaxPricePerHour types.Float64       `tfsdk:"max_price_per_hour"`
+	Location        types.String        `tfsdk:"location"`
+	InstanceTypes   []InstanceTypeModel `tfsdk:"instance_types"`
+}
+
+type InstanceTypeModel struct {
+	ID           types.String  `tfsdk:"id"`
+	InstanceType types.String  `tfsdk:"instance_type"`
+	Name         types.String  `tfsdk:"name"`
+	Model        types.String  `tfsdk:"model"`
+	Manufacturer types.String  `tfsdk:"manufacturer"`
+	Description  types.String  `tfsdk:"description"`
+	CPU          types.Object  `tfsdk:"cpu"`
+	GPU          types.Object  `tfsdk:"gpu"`
+	Memory       types.Object  `tfsdk:"memory"`
+	GPUMemory    types.Object  `tfsdk:"gpu_memory"`
+	Storage      types.Object  `tfsdk:"storage"`
+	PricePerHour types.Float64 `tfsdk:"price_per_hour"`
+	SpotPrice    types.Float64 `tfsdk:"spot_price"`
+	Currency     types.String  `tfsdk:"currency"`
+}
+
+func (d *InstanceTypesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_instance_types"
+}
+
+func (d *InstanceTypesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the Verda instance types with their hardware specifications and pricing",
+
+		Attributes: map[string]schema.Attribute{
+			"gpu_model": schema.StringAttribute{
+				MarkdownDescription: "Only return instance types whose GPU model or GPU description contains this value (case-insensitive, e.g., 'B200')",
+				Optional:            true,
+			},
+			"min_gpu_count": schema.Int64Attribute{
+				MarkdownDescription: "Only return instance types with at least this many GPUs",
+				Optional:            true,
+			},
+			"max_price_per_hour": schema.Float64Attribute{
+				MarkdownDescription: "Only return instance types whose on-demand price per hour is at most this value",
+				Optional:            true,
+			},
+			"location": schema.StringAttribute{
+				MarkdownDescription: "Only return instance types currently available in this location (e.g., 'FIN-01')",
+				Optional:            true,
+			},
+			"instance_types": schema.ListNestedAttribute{
+				MarkdownDescription: "Instance types matching the filters",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: instanceTypeDataSourceAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// instanceTypeDataSourceAttributes returns the attributes of a single instance type,
+// using the same nested hardware shapes as verda_instance
+func instanceTypeDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: "Instance type identifier",
+			Computed:            true,
+		},
+		"instance_type": schema.StringAttribute{
+			MarkdownDescription: "Instance type name to use in `verda_instance.instance_type` (e.g., '1B200.30V')",
+			Computed:            true,
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: "Display name of the instance type",
+			Computed:            true,
+		},
+		"model": schema.StringAttribute{
+			MarkdownDescription: "GPU model (e.g., 'B200')",
+			Computed:            true,
+		},
+		"manufacturer": schema.StringAttribute{
+			MarkdownDescription: "Hardware manufacturer",
+			Computed:            true,
+		},
+		"description": schema.StringAttribute{
+			MarkdownDescription: "Description of the instance type",
+			Computed:            true,
+		},
+		"cpu": schema.SingleNestedAttribute{
+			MarkdownDescription: "CPU information",
+			Computed:            true,
+			Attributes: map[string]schema.Attribute{
+				"description": schema.StringAttribute{
+					Computed: true,
+				},
+				"number_of_cores": schema.Int64Attribute{
+					Computed: true,
+				},
+			},
+		},
+		"gpu": schema.SingleNestedAttribute{
+			MarkdownDescription: "GPU information",
+			Computed:            true,
+			Attributes: map[string]schema.Attribute{
+				"description": schema.StringAttribute{
+					Computed: true,
+				},
+				"number_of_gpus": schema.Int64Attribute{
+					Computed: true,
+				},
+			},
+		},
+		"memory": schema.SingleNestedAttribute{
+			MarkdownDescription: "Memory information",
+			Computed:            true,
+			Attributes: map[string]schema.Attribute{
+				"description": schema.StringAttribute{
+					Computed: true,
+				},
+				"size_in_gigabytes": schema.Int64Attribute{
+					Computed: true,
+				},
+			},
+		},
+		"gpu_memory": schema.SingleNestedAttribute{
+			MarkdownDescription: "GPU memory information",
+			Computed:            true,
+			Attributes: map[string]schema.Attribute{
+				"description": schema.StringAttribute{
+					Computed: true,
+				},
+				"size_in_gigabytes": schema.Int64Attribute{
+					Computed: true,
+				},
+			},
+		},
+		"storage": schema.SingleNestedAttribute{
+			MarkdownDescription: "Storage information",
+			Computed:            true,
+			Attributes: map[string]schema.Attribute{
+				"description": schema.StringAttribute{
+					Computed: true,
+				},
+			},
+		},
+		"price_per_hour": schema.Float64Attribute{
+			MarkdownDescription: "On-demand price per hour",
+			Computed:            true,
+		},
+		"spot_price": schema.Float64Attribute{
+			MarkdownDescription: "Spot price per hour",
+			Computed:            true,
+		},
+		"currency": schema.StringAttribute{
+			MarkdownDescription: "Currency of the prices",
+			Computed:            true,
+		},
+	}
+}
+
+func (d *InstanceTypesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*verda.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *verda.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *InstanceTypesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data InstanceTypesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	instanceTypes, err := d.client.InstanceTypes.Get(ctx, "")
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read instance types, got error: %s", err))
+		return
+	}
+
+	// The instance types endpoint is global, so the location filter is based on
+	// what the availability endpoint reports for that location
+	var availableInLocation map[string]bool
+	if !data.Location.IsNull() && data.Location.ValueString() != "" {
+		availabilities, err := d.client.InstanceAvailability.GetAllAvailabilities(ctx, false, data.Location.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read instance availability, got error: %s", err))
+			return
+		}
+
+		availableInLocation = make(map[string]bool)
+		for _, availability := range availabilities {
+			if availability.LocationCode != data.Location.ValueString() {
+				continue
+			}
+			for _, instanceType := range availability.Availabilities {
+				availableInLocation[instanceType] = true
+			}
+		}
+	}
+
+	data.InstanceTypes = []InstanceTypeModel{}
+	for _, instanceType := range instanceTypes {
+		if !data.GPUModel.IsNull() && data.GPUModel.ValueString() != "" {
+			gpuModel := strings.ToLower(data.GPUModel.ValueString())
+			if !strings.Contains(strings.ToLower(instanceType.Model), gpuModel) &&
+				!strings.Contains(strings.ToLower(instanceType.GPU.Description), gpuModel) {
+				continue
+			}
+		}
+
+		if !data.MinGPUCount.IsNull() && int64(instanceType.GPU.NumberOfGPUs) < data.MinGPUCount.ValueInt64() {
+			continue
+		}
+
+		if !data.MaxPricePerHour.IsNull() && instanceType.PricePerHour.Float64() > data.MaxPricePerHour.ValueFloat64() {
+			continue
+		}
+
+		if availableInLocation != nil && !availableInLocation[instanceType.InstanceType] {
+			continue
+		}
+
+		data.InstanceTypes = append(data.InstanceTypes, flattenInstanceTypeToModel(instanceType, &resp.Diagnostics))
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func flattenInstanceTypeToModel(instanceType verda.InstanceTypeInfo, diagnostics *diag.Diagnostics) InstanceTypeModel {
+	model := InstanceTypeModel{
+		ID:           types.StringValue(instanceType.ID),
+		InstanceType: types.StringValue(instanceType.InstanceType),
+		Name:         types.StringValue(instanceType.Name),
+		Model:        types.StringValue(instanceType.Model),
+		Manufacturer: types.StringValue(instanceType.Manufacturer),
+		Description:  types.StringValue(instanceType.Description),
+		PricePerHour: types.Float64Value(instanceType.PricePerHour.Float64()),
+		SpotPrice:    types.Float64Value(instanceType.SpotPrice.Float64()),
+		Currency:     types.StringValue(instanceType.Currency),
+	}
+
+	cpuObj, diags := flattenInstanceCPU(instanceType.CPU)
+	diagnostics.Append(diags...)
+	model.CPU = cpuObj
+
+	gpuObj, diags := flattenInstanceGPU(instanceType.GPU)
+	diagnostics.Append(diags...)
+	model.GPU = gpuObj
+
+	memoryObj, diags := flattenInstanceMemory(instanceType.Memory)
+	diagnostics.Append(diags...)
+	model.Memory = memoryObj
+
+	gpuMemoryObj, diags := flattenInstanceMemory(instanceType.GPUMemory)
+	diagnostics.Append(diags...)
+	model.GPUMemory = gpuMemoryObj
+
+	storageObj, diags := flattenInstanceStorage(instanceType.Storage)
+	diagnostics.Append(diags...)
+	model.Storage = storageObj
+
+	return model
+}
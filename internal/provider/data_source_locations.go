@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda"
+)
+
+var _ datasource.DataSource = &LocationsDataSource{}
+
+func NewLocationsDataSource() datasource.DataSource {
+	return &LocationsDataSource{}
+}
+
+type LocationsDataSource struct {
+	client *verda.Client
+}
+
+type LocationsDataSourceModel struct {
+	Locations []LocationModel `tfsdk:"locations"`
+}
+
+type LocationModel struct {
+	Code                   types.String `tfsdk:"code"`
+	Name                   types.String `tfsdk:"name"`
+	CountryCode            types.String `tfsdk:"country_code"`
+	AvailableInstanceTypes types.List   `tfsdk:"available_instance_types"`
+}
+
+func (d *LocationsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_locations"
+}
+
+func (d *LocationsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the Verda data center locations",
+
+		Attributes: map[string]schema.Attribute{
+			"locations": schema.ListNestedAttribute{
+				MarkdownDescription: "Available locations",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"code": schema.StringAttribute{
+							MarkdownDescription: "Location code to use in `location` arguments (e.g., 'FIN-01')",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Display name of the location",
+							Computed:            true,
+						},
+						"country_code": schema.StringAttribute{
+							MarkdownDescription: "Country code of the location",
+							Computed:            true,
+						},
+						"available_instance_types": schema.ListAttribute{
+							MarkdownDescription: "Instance types currently available on-demand in this location",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *LocationsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*verda.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *verda.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *LocationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data LocationsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	locations, err := d.client.Locations.Get(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read locations, got error: %s", err))
+		return
+	}
+
+	availabilities, err := d.client.InstanceAvailability.GetAllAvailabilities(ctx, false, "")
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read instance availability, got error: %s", err))
+		return
+	}
+
+	instanceTypesByLocation := make(map[string][]string)
+	for _, availability := range availabilities {
+		instanceTypesByLocation[availability.LocationCode] = append(instanceTypesByLocation[availability.LocationCode], availability.Availabilities...)
+	}
+
+	data.Locations = []LocationModel{}
+	for _, location := range locations {
+		instanceTypes := instanceTypesByLocation[location.Code]
+		if instanceTypes == nil {
+			instanceTypes = []string{}
+		}
+		sort.Strings(instanceTypes)
+
+		instanceTypesList, diags := types.ListValueFrom(ctx, types.StringType, instanceTypes)
+		resp.Diagnostics.Append(diags...)
+
+		data.Locations = append(data.Locations, LocationModel{
+			Code:                   types.StringValue(location.Code),
+			Name:                   types.StringValue(location.Name),
+			CountryCode:            types.StringValue(location.CountryCode),
+			AvailableInstanceTypes: instanceTypesList,
+		})
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// deletionProtectionAttribute returns the deletion_protection attribute shared by resources
+// that hold long-lived infrastructure
+func deletionProtectionAttribute(kind string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		MarkdownDescription: fmt.Sprintf("Prevent the %s from being destroyed or replaced (defaults to false). "+
+			"Set it to false in a separate apply before destroying the %s.", kind, kind),
+		Optional: true,
+	}
+}
+
+// checkDeletionProtection adds an error and returns true when the state has deletion_protection enabled
+func checkDeletionProtection(protection types.Bool, kind string, name string, diags *diag.Diagnostics) bool {
+	if !protection.ValueBool() {
+		return false
+	}
+
+	diags.AddError(
+		"Deletion Protection Enabled",
+		fmt.Sprintf("The %s %s has deletion_protection enabled and cannot be destroyed or replaced. "+
+			"Set deletion_protection = false and apply that change first, then destroy the %s.", kind, name, kind),
+	)
+	return true
+}
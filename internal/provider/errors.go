@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda"
+)
+
+// isNotFoundError reports whether the API answered with 404 Not Found, which means the
+// resource was deleted outside of Terraform
+func isNotFoundError(err error) bool {
+	var apiErr *verda.APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// isAmbiguousCreateError reports whether a failed create may still have created the object:
+// the request timed out, the connection failed, or the API failed with a server error. Errors
+// returned before the request was sent, such as SDK validation errors, and 4xx responses,
+// including 409 Conflict for an object that already existed, are not ambiguous.
+func isAmbiguousCreateError(err error) bool {
+	var apiErr *verda.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusRequestTimeout || apiErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	var urlErr *url.Error
+	return errors.As(err, &urlErr) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// ambiguousCreateLookupTimeout bounds the lookup for an object left behind by an ambiguous create failure
+const ambiguousCreateLookupTimeout = time.Minute
+
+// ambiguousCreateLookupContext returns the context for looking up an object left behind by an
+// ambiguous create failure. The create context has expired if the create timed out, so the
+// lookup keeps its values but gets a short timeout of its own.
+func ambiguousCreateLookupContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(ctx), ambiguousCreateLookupTimeout)
+}
+
+// unverifiedCreateHint explains that an object whose secrets the API does not return was found
+// after an ambiguous create failure. It cannot be compared with the configuration, so it is
+// not adopted into the state.
+func unverifiedCreateHint(object string, name string) string {
+	return fmt.Sprintf("\n\nFound %s named %s, probably created by that request. The API does not return secret values, "+
+		"so the existing object cannot be compared with the configuration and was not adopted into the state. "+
+		"Import it with 'terraform import' if it is correct, or delete it and apply again.", object, name)
+}
+
+// errInsufficientCapacity marks failures caused by a location running out of capacity
+var errInsufficientCapacity = errors.New("insufficient capacity")
+
+// isCapacityError reports whether the API rejected an instance create because the location
+// has no capacity for the instance type. The API has no dedicated error code for this, so
+// 503 Service Unavailable and errors mentioning capacity are treated as such.
+func isCapacityError(err error) bool {
+	var apiErr *verda.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	return apiErr.StatusCode == http.StatusServiceUnavailable ||
+		strings.Contains(strings.ToLower(apiErr.Code), "capacity") ||
+		strings.Contains(strings.ToLower(apiErr.Message), "capacity")
+}
@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// listCacheTTL is how long a list response is reused. It only needs to cover one refresh,
+// where Terraform reads many resources of the same type at once.
+const listCacheTTL = 15 * time.Second
+
+// cachedListPaths are the list endpoints whose responses are shared between reads
+var cachedListPaths = []string{
+	"/container-registry-credentials",
+	"/file-secrets",
+	"/secrets",
+	"/ssh-keys",
+	"/scripts",
+	"/volumes",
+}
+
+// listCacheTransport is a read-through cache for list endpoints. Concurrent reads of the same
+// list share one API call, and any write request invalidates all cached lists, so resources
+// never see a list from before their own changes.
+type listCacheTransport struct {
+	next http.RoundTripper
+	ttl  time.Duration
+
+	mu         sync.Mutex
+	entries    map[string]*listCacheEntry
+	generation uint64
+}
+
+type listCacheEntry struct {
+	// done is closed once the response below is filled in
+	done       chan struct{}
+	generation uint64
+	expires    time.Time
+
+	statusCode int
+	header     http.Header
+	body       []byte
+	err        error
+}
+
+func newListCacheTransport(next http.RoundTripper, ttl time.Duration) *listCacheTransport {
+	return &listCacheTransport{
+		next:    next,
+		ttl:     ttl,
+		entries: make(map[string]*listCacheEntry),
+	}
+}
+
+func (t *listCacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		if !isTokenRequest(req) {
+			// Invalidate before and after the write, so no read started in between is cached
+			t.invalidate()
+			defer t.invalidate()
+		}
+		return t.next.RoundTrip(req)
+	}
+
+	if !isCachedListRequest(req) {
+		return t.next.RoundTrip(req)
+	}
+
+	key := req.URL.String()
+
+	t.mu.Lock()
+	entry, ok := t.entries[key]
+	if ok && entry.generation == t.generation && (!isDone(entry.done) || time.Now().Before(entry.expires)) {
+		t.mu.Unlock()
+		return t.wait(req, entry)
+	}
+
+	entry = &listCacheEntry{done: make(chan struct{}), generation: t.generation}
+	t.entries[key] = entry
+	t.mu.Unlock()
+
+	resp, err := t.next.RoundTrip(req)
+	if err == nil {
+		entry.statusCode = resp.StatusCode
+		entry.header = resp.Header.Clone()
+		entry.body, err = io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+	}
+	entry.err = err
+	entry.expires = time.Now().Add(t.ttl)
+
+	t.mu.Lock()
+	// Only successful responses are reused, errors are returned to the waiting reads only
+	if err != nil || entry.statusCode != http.StatusOK {
+		if t.entries[key] == entry {
+			delete(t.entries, key)
+		}
+	}
+	t.mu.Unlock()
+	close(entry.done)
+
+	return entry.response(req)
+}
+
+// wait returns the response of an entry once it is filled in, or stops when the request is canceled
+func (t *listCacheTransport) wait(req *http.Request, entry *listCacheEntry) (*http.Response, error) {
+	select {
+	case <-entry.done:
+		// The shared call may have failed because the request that started it was canceled
+		if entry.err != nil {
+			return t.next.RoundTrip(req)
+		}
+		return entry.response(req)
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+}
+
+func (t *listCacheTransport) invalidate() {
+	t.mu.Lock()
+	t.generation++
+	clear(t.entries)
+	t.mu.Unlock()
+}
+
+// response builds a new response from the cached entry, so every caller can read the body
+func (e *listCacheEntry) response(req *http.Request) (*http.Response, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+
+	return &http.Response{
+		Status:        http.StatusText(e.statusCode),
+		StatusCode:    e.statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}, nil
+}
+
+// isCachedListRequest reports whether the request lists one of the cached resource types
+func isCachedListRequest(req *http.Request) bool {
+	if req.URL.RawQuery != "" {
+		return false
+	}
+
+	for _, path := range cachedListPaths {
+		if strings.HasSuffix(req.URL.Path, path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isDone(done chan struct{}) bool {
+	select {
+	case <-done:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestListCacheTransport(t *testing.T) {
+	type request struct {
+		method string
+		path   string
+	}
+
+	tests := []struct {
+		name      string
+		ttl       time.Duration
+		status    int
+		requests  []request
+		wantCalls map[string]int
+	}{
+		{
+			name:      "list is reused",
+			requests:  []request{{http.MethodGet, "/ssh-keys"}, {http.MethodGet, "/ssh-keys"}, {http.MethodGet, "/ssh-keys"}},
+			wantCalls: map[string]int{"GET /ssh-keys": 1},
+		},
+		{
+			name:      "lists are cached per path",
+			requests:  []request{{http.MethodGet, "/ssh-keys"}, {http.MethodGet, "/scripts"}, {http.MethodGet, "/ssh-keys"}, {http.MethodGet, "/scripts"}},
+			wantCalls: map[string]int{"GET /ssh-keys": 1, "GET /scripts": 1},
+		},
+		{
+			name:      "create invalidates the cache",
+			requests:  []request{{http.MethodGet, "/ssh-keys"}, {http.MethodPost, "/ssh-keys"}, {http.MethodGet, "/ssh-keys"}},
+			wantCalls: map[string]int{"GET /ssh-keys": 2, "POST /ssh-keys": 1},
+		},
+		{
+			name:      "delete invalidates other lists",
+			requests:  []request{{http.MethodGet, "/volumes"}, {http.MethodGet, "/secrets"}, {http.MethodDelete, "/secrets/name"}, {http.MethodGet, "/volumes"}, {http.MethodGet, "/secrets"}},
+			wantCalls: map[string]int{"GET /volumes": 2, "GET /secrets": 2, "DELETE /secrets/name": 1},
+		},
+		{
+			name:      "volume action invalidates the cache",
+			requests:  []request{{http.MethodGet, "/volumes"}, {http.MethodPut, "/volumes"}, {http.MethodGet, "/volumes"}},
+			wantCalls: map[string]int{"GET /volumes": 2, "PUT /volumes": 1},
+		},
+		{
+			name:      "token request does not invalidate the cache",
+			requests:  []request{{http.MethodGet, "/ssh-keys"}, {http.MethodPost, "/oauth2/token"}, {http.MethodGet, "/ssh-keys"}},
+			wantCalls: map[string]int{"GET /ssh-keys": 1, "POST /oauth2/token": 1},
+		},
+		{
+			name:      "other endpoints are not cached",
+			requests:  []request{{http.MethodGet, "/instances"}, {http.MethodGet, "/instances"}, {http.MethodGet, "/ssh-keys/key-id"}, {http.MethodGet, "/ssh-keys/key-id"}},
+			wantCalls: map[string]int{"GET /instances": 2, "GET /ssh-keys/key-id": 2},
+		},
+		{
+			name:      "lists with a query are not cached",
+			requests:  []request{{http.MethodGet, "/volumes?status=deleted"}, {http.MethodGet, "/volumes?status=deleted"}},
+			wantCalls: map[string]int{"GET /volumes": 2},
+		},
+		{
+			name:      "expired list is fetched again",
+			ttl:       time.Nanosecond,
+			requests:  []request{{http.MethodGet, "/ssh-keys"}, {http.MethodGet, "/ssh-keys"}},
+			wantCalls: map[string]int{"GET /ssh-keys": 2},
+		},
+		{
+			name:      "error responses are not cached",
+			status:    http.StatusInternalServerError,
+			requests:  []request{{http.MethodGet, "/ssh-keys"}, {http.MethodGet, "/ssh-keys"}},
+			wantCalls: map[string]int{"GET /ssh-keys": 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := tt.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			var mu sync.Mutex
+			calls := map[string]int{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				calls[r.Method+" "+r.URL.Path]++
+				mu.Unlock()
+
+				w.WriteHeader(status)
+				_, _ = w.Write([]byte(`[{"path":"` + r.URL.Path + `"}]`))
+			}))
+			defer server.Close()
+
+			ttl := tt.ttl
+			if ttl == 0 {
+				ttl = listCacheTTL
+			}
+			transport := newListCacheTransport(server.Client().Transport, ttl)
+
+			for _, r := range tt.requests {
+				req, err := http.NewRequest(r.method, server.URL+r.path, nil)
+				if err != nil {
+					t.Fatalf("NewRequest() error = %v", err)
+				}
+
+				resp, err := transport.RoundTrip(req)
+				if err != nil {
+					t.Fatalf("RoundTrip(%s %s) error = %v", r.method, r.path, err)
+				}
+				body, _ := io.ReadAll(resp.Body)
+				_ = resp.Body.Close()
+
+				// Cached responses must carry the full body of the original response
+				if want := `[{"path":"` + req.URL.Path + `"}]`; string(body) != want {
+					t.Errorf("%s %s body = %s, want %s", r.method, r.path, body, want)
+				}
+				if resp.StatusCode != status {
+					t.Errorf("%s %s status = %d, want %d", r.method, r.path, resp.StatusCode, status)
+				}
+			}
+
+			for key, want := range tt.wantCalls {
+				if got := calls[key]; got != want {
+					t.Errorf("calls to %s = %d, want %d", key, got, want)
+				}
+			}
+			for key, got := range calls {
+				if _, ok := tt.wantCalls[key]; !ok {
+					t.Errorf("unexpected %d calls to %s", got, key)
+				}
+			}
+		})
+	}
+}
+
+func TestListCacheTransportConcurrentReads(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-release
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	transport := newListCacheTransport(server.Client().Transport, listCacheTTL)
+
+	const reads = 5
+	var wg sync.WaitGroup
+	for range reads {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			req, err := http.NewRequest(http.MethodGet, server.URL+"/ssh-keys", nil)
+			if err != nil {
+				t.Errorf("NewRequest() error = %v", err)
+				return
+			}
+			resp, err := transport.RoundTrip(req)
+			if err != nil {
+				t.Errorf("RoundTrip() error = %v", err)
+				return
+			}
+			_ = resp.Body.Close()
+		}()
+	}
+
+	// Give all reads time to join the call in flight before it completes
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("calls = %d, want 1 shared call for %d concurrent reads", got, reads)
+	}
+}
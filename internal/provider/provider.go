@@ -3,13 +3,18 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda"
 )
@@ -21,9 +26,14 @@ type VerdaProvider struct {
 }
 
 type VerdaProviderModel struct {
-	ClientID     types.String `tfsdk:"client_id"`
-	ClientSecret types.String `tfsdk:"client_secret"`
-	BaseURL      types.String `tfsdk:"base_url"`
+	ClientID             types.String  `tfsdk:"client_id"`
+	ClientSecret         types.String  `tfsdk:"client_secret"`
+	BaseURL              types.String  `tfsdk:"base_url"`
+	MaxRetries           types.Int64   `tfsdk:"max_retries"`
+	RetryMinBackoff      types.String  `tfsdk:"retry_min_backoff"`
+	RetryMaxBackoff      types.String  `tfsdk:"retry_max_backoff"`
+	RequestsPerSecond    types.Float64 `tfsdk:"requests_per_second"`
+	MaxConcurrentCreates types.Int64   `tfsdk:"max_concurrent_creates"`
 }
 
 func New(version string) func() provider.Provider {
@@ -55,6 +65,32 @@ func (p *VerdaProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				MarkdownDescription: "Verda API Base URL. Defaults to https://api.verda.com/v1. Can also be set via VERDA_BASE_URL environment variable.",
 				Optional:            true,
 			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of retries for API requests that failed with a transient error (429 or 5xx). Defaults to 5, 0 disables retries. Can also be set via VERDA_MAX_RETRIES environment variable.",
+				Optional:            true,
+			},
+			"retry_min_backoff": schema.StringAttribute{
+				MarkdownDescription: "Initial wait between retries, doubled on every retry (e.g., '1s'). Defaults to 1s. Can also be set via VERDA_RETRY_MIN_BACKOFF environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"retry_max_backoff": schema.StringAttribute{
+				MarkdownDescription: "Maximum wait between retries (e.g., '30s'). Defaults to 30s. A Retry-After header sent by the API takes precedence. Can also be set via VERDA_RETRY_MAX_BACKOFF environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "Maximum number of API requests per second, shared by all resources and data sources (e.g., 5 or 0.5). Unlimited when unset or 0. Can also be set via VERDA_REQUESTS_PER_SECOND environment variable.",
+				Optional:            true,
+			},
+			"max_concurrent_creates": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of create requests sent to the API at the same time. Unlimited when unset or 0. Can also be set via VERDA_MAX_CONCURRENT_CREATES environment variable.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -84,6 +120,9 @@ func (p *VerdaProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		baseURL = data.BaseURL.ValueString()
 	}
 
+	maxRetries, minBackoff, maxBackoff := retrySettings(data, &resp.Diagnostics)
+	requestsPerSecond, maxConcurrentCreates := rateLimitSettings(data, &resp.Diagnostics)
+
 	if clientID == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("client_id"),
@@ -115,6 +154,16 @@ func (p *VerdaProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		verda.WithClientID(clientID),
 		verda.WithClientSecret(clientSecret),
 		verda.WithUserAgent(userAgent),
+		verda.WithHTTPClient(&http.Client{
+			// Cached list responses skip the API entirely, and every retry attempt goes through the rate limiter
+			Transport: newListCacheTransport(
+				newRetryTransport(
+					newRateLimitTransport(http.DefaultTransport, requestsPerSecond, maxConcurrentCreates),
+					maxRetries, minBackoff, maxBackoff,
+				),
+				listCacheTTL,
+			),
+		}),
 	}
 
 	if baseURL != "" {
@@ -136,18 +185,134 @@ func (p *VerdaProvider) Configure(ctx context.Context, req provider.ConfigureReq
 	resp.ResourceData = client
 }
 
+// retrySettings reads the retry configuration from the provider configuration, falling back
+// to the environment and then to the defaults
+func retrySettings(data VerdaProviderModel, diags *diag.Diagnostics) (int, time.Duration, time.Duration) {
+	maxRetries := defaultMaxRetries
+	minBackoff := defaultRetryMinBackoff
+	maxBackoff := defaultRetryMaxBackoff
+
+	if value := os.Getenv("VERDA_MAX_RETRIES"); value != "" {
+		retries, err := strconv.Atoi(value)
+		if err != nil || retries < 0 {
+			diags.AddError("Invalid Retry Configuration", fmt.Sprintf("VERDA_MAX_RETRIES must be a non-negative integer, got: '%s'", value))
+		} else {
+			maxRetries = retries
+		}
+	}
+
+	if !data.MaxRetries.IsNull() {
+		if data.MaxRetries.ValueInt64() < 0 {
+			diags.AddAttributeError(path.Root("max_retries"), "Invalid Retry Configuration", "max_retries must not be negative")
+		} else {
+			maxRetries = int(data.MaxRetries.ValueInt64())
+		}
+	}
+
+	minBackoff = durationSetting(data.RetryMinBackoff, "retry_min_backoff", "VERDA_RETRY_MIN_BACKOFF", minBackoff, diags)
+	maxBackoff = durationSetting(data.RetryMaxBackoff, "retry_max_backoff", "VERDA_RETRY_MAX_BACKOFF", maxBackoff, diags)
+
+	if minBackoff > maxBackoff {
+		diags.AddAttributeError(
+			path.Root("retry_min_backoff"),
+			"Invalid Retry Configuration",
+			fmt.Sprintf("retry_min_backoff (%s) must not be greater than retry_max_backoff (%s)", minBackoff, maxBackoff),
+		)
+	}
+
+	return maxRetries, minBackoff, maxBackoff
+}
+
+// rateLimitSettings reads the rate limits from the provider configuration, falling back to
+// the environment. Zero means unlimited.
+func rateLimitSettings(data VerdaProviderModel, diags *diag.Diagnostics) (float64, int) {
+	var requestsPerSecond float64
+	var maxConcurrentCreates int
+
+	if value := os.Getenv("VERDA_REQUESTS_PER_SECOND"); value != "" {
+		rps, err := strconv.ParseFloat(value, 64)
+		if err != nil || rps < 0 {
+			diags.AddError("Invalid Rate Limit Configuration", fmt.Sprintf("VERDA_REQUESTS_PER_SECOND must be a non-negative number, got: '%s'", value))
+		} else {
+			requestsPerSecond = rps
+		}
+	}
+
+	if !data.RequestsPerSecond.IsNull() {
+		if data.RequestsPerSecond.ValueFloat64() < 0 {
+			diags.AddAttributeError(path.Root("requests_per_second"), "Invalid Rate Limit Configuration", "requests_per_second must not be negative")
+		} else {
+			requestsPerSecond = data.RequestsPerSecond.ValueFloat64()
+		}
+	}
+
+	if value := os.Getenv("VERDA_MAX_CONCURRENT_CREATES"); value != "" {
+		creates, err := strconv.Atoi(value)
+		if err != nil || creates < 0 {
+			diags.AddError("Invalid Rate Limit Configuration", fmt.Sprintf("VERDA_MAX_CONCURRENT_CREATES must be a non-negative integer, got: '%s'", value))
+		} else {
+			maxConcurrentCreates = creates
+		}
+	}
+
+	if !data.MaxConcurrentCreates.IsNull() {
+		if data.MaxConcurrentCreates.ValueInt64() < 0 {
+			diags.AddAttributeError(path.Root("max_concurrent_creates"), "Invalid Rate Limit Configuration", "max_concurrent_creates must not be negative")
+		} else {
+			maxConcurrentCreates = int(data.MaxConcurrentCreates.ValueInt64())
+		}
+	}
+
+	return requestsPerSecond, maxConcurrentCreates
+}
+
+// durationSetting returns the duration from the configuration or the environment variable,
+// or the default when neither is set
+func durationSetting(value types.String, attribute string, envVar string, defaultValue time.Duration, diags *diag.Diagnostics) time.Duration {
+	setting := os.Getenv(envVar)
+	if !value.IsNull() {
+		setting = value.ValueString()
+	}
+
+	if setting == "" {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(setting)
+	if err != nil || duration < 0 {
+		diags.AddAttributeError(
+			path.Root(attribute),
+			"Invalid Retry Configuration",
+			fmt.Sprintf("%s must be a valid non-negative duration (e.g., '1s'), got: '%s'. It can also be set via %s.", attribute, setting, envVar),
+		)
+		return defaultValue
+	}
+
+	return duration
+}
+
 func (p *VerdaProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewInstanceResource,
 		NewSSHKeyResource,
 		NewStartupScriptResource,
 		NewVolumeResource,
+		NewVolumeAttachmentResource,
+		NewVolumeRestoreResource,
 		NewContainerResource,
 		NewContainerRegistryCredentialsResource,
+		NewContainerSecretResource,
+		NewContainerFileSecretResource,
 		NewServerlessJobResource,
 	}
 }
 
 func (p *VerdaProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewInstanceTypesDataSource,
+		NewImagesDataSource,
+		NewImageDataSource,
+		NewLocationsDataSource,
+		NewInstanceAvailabilityDataSource,
+	}
 }
@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// rateLimitTransport spaces out API requests to the configured rate and caps the number of
+// create requests in flight. It is shared by all resources and data sources of a provider
+// instance, so it also smooths bursts caused by Terraform's parallelism.
+type rateLimitTransport struct {
+	next    http.RoundTripper
+	limiter *requestLimiter
+	creates chan struct{}
+}
+
+// newRateLimitTransport returns a transport that sends at most requestsPerSecond requests per
+// second and at most maxConcurrentCreates create requests at a time. Zero disables a limit.
+func newRateLimitTransport(next http.RoundTripper, requestsPerSecond float64, maxConcurrentCreates int) *rateLimitTransport {
+	t := &rateLimitTransport{next: next}
+
+	if requestsPerSecond > 0 {
+		t.limiter = &requestLimiter{interval: time.Duration(float64(time.Second) / requestsPerSecond)}
+	}
+
+	if maxConcurrentCreates > 0 {
+		t.creates = make(chan struct{}, maxConcurrentCreates)
+	}
+
+	return t
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if t.creates != nil && isCreateRequest(req) {
+		select {
+		case t.creates <- struct{}{}:
+			defer func() { <-t.creates }()
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if t.limiter != nil {
+		if err := t.limiter.wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return t.next.RoundTrip(req)
+}
+
+// isCreateRequest reports whether the request creates a resource in the account
+func isCreateRequest(req *http.Request) bool {
+	return req.Method == http.MethodPost && !isTokenRequest(req)
+}
+
+// requestLimiter hands out evenly spaced time slots for requests
+type requestLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// wait blocks until the next free slot, or until the context is done
+func (l *requestLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
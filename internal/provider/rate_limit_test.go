@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimitTransportRequestsPerSecond(t *testing.T) {
+	tests := []struct {
+		name              string
+		requestsPerSecond float64
+		requests          int
+		wantMinElapsed    time.Duration
+	}{
+		{name: "spaced to the rate", requestsPerSecond: 20, requests: 5, wantMinElapsed: 200 * time.Millisecond},
+		{name: "first request is not delayed", requestsPerSecond: 1, requests: 1, wantMinElapsed: 0},
+		{name: "disabled", requestsPerSecond: 0, requests: 5, wantMinElapsed: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+			defer server.Close()
+
+			transport := newRateLimitTransport(server.Client().Transport, tt.requestsPerSecond, 0)
+
+			start := time.Now()
+			for range tt.requests {
+				req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+				if err != nil {
+					t.Fatalf("NewRequest() error = %v", err)
+				}
+				resp, err := transport.RoundTrip(req)
+				if err != nil {
+					t.Fatalf("RoundTrip() error = %v", err)
+				}
+				_ = resp.Body.Close()
+			}
+			elapsed := time.Since(start)
+
+			if elapsed < tt.wantMinElapsed {
+				t.Errorf("%d requests took %s, want at least %s", tt.requests, elapsed, tt.wantMinElapsed)
+			}
+			if tt.wantMinElapsed == 0 && elapsed > time.Second {
+				t.Errorf("%d requests took %s, want no delay", tt.requests, elapsed)
+			}
+		})
+	}
+}
+
+func TestRateLimitTransportConcurrentCreates(t *testing.T) {
+	tests := []struct {
+		name                 string
+		method               string
+		path                 string
+		maxConcurrentCreates int
+		requests             int
+		wantMaxInFlight      int
+	}{
+		{name: "creates are capped", method: http.MethodPost, maxConcurrentCreates: 2, requests: 6, wantMaxInFlight: 2},
+		{name: "reads are not capped", method: http.MethodGet, maxConcurrentCreates: 2, requests: 6, wantMaxInFlight: 6},
+		{name: "token requests are not capped", method: http.MethodPost, path: "/oauth2/token", maxConcurrentCreates: 2, requests: 6, wantMaxInFlight: 6},
+		{name: "disabled", method: http.MethodPost, maxConcurrentCreates: 0, requests: 6, wantMaxInFlight: 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var inFlight, maxInFlight atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				current := inFlight.Add(1)
+				defer inFlight.Add(-1)
+				for {
+					seen := maxInFlight.Load()
+					if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+						break
+					}
+				}
+
+				// Hold the requests until as many as may run in parallel have arrived, and a
+				// little longer so requests beyond the limit would show up as well
+				deadline := time.Now().Add(time.Second)
+				for maxInFlight.Load() < int32(tt.wantMaxInFlight) && time.Now().Before(deadline) {
+					time.Sleep(time.Millisecond)
+				}
+				time.Sleep(20 * time.Millisecond)
+			}))
+			defer server.Close()
+
+			transport := newRateLimitTransport(server.Client().Transport, 0, tt.maxConcurrentCreates)
+
+			var wg sync.WaitGroup
+			for range tt.requests {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+
+					req, err := http.NewRequest(tt.method, server.URL+tt.path, nil)
+					if err != nil {
+						t.Errorf("NewRequest() error = %v", err)
+						return
+					}
+					resp, err := transport.RoundTrip(req)
+					if err != nil {
+						t.Errorf("RoundTrip() error = %v", err)
+						return
+					}
+					_ = resp.Body.Close()
+				}()
+			}
+			wg.Wait()
+
+			if got := int(maxInFlight.Load()); got != tt.wantMaxInFlight {
+				t.Errorf("max requests in flight = %d, want %d", got, tt.wantMaxInFlight)
+			}
+		})
+	}
+}
+
+func TestRateLimitTransportContextCanceled(t *testing.T) {
+	tests := []struct {
+		name                 string
+		requestsPerSecond    float64
+		maxConcurrentCreates int
+	}{
+		{name: "waiting for a rate slot", requestsPerSecond: 0.001},
+		{name: "waiting for a create slot", maxConcurrentCreates: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+			}))
+			defer server.Close()
+
+			transport := newRateLimitTransport(server.Client().Transport, tt.requestsPerSecond, tt.maxConcurrentCreates)
+
+			// Use up the only slot
+			if transport.limiter != nil {
+				if err := transport.limiter.wait(context.Background()); err != nil {
+					t.Fatalf("wait() error = %v", err)
+				}
+			}
+			if transport.creates != nil {
+				transport.creates <- struct{}{}
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL, nil)
+			if err != nil {
+				t.Fatalf("NewRequest() error = %v", err)
+			}
+
+			resp, err := transport.RoundTrip(req)
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("RoundTrip() error = %v, want %v", err, context.DeadlineExceeded)
+			}
+			if got := calls.Load(); got != 0 {
+				t.Errorf("calls = %d, want 0", got)
+			}
+		})
+	}
+}
+
+func TestIsCreateRequest(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		want   bool
+	}{
+		{method: http.MethodPost, path: "/v1/instances", want: true},
+		{method: http.MethodPost, path: "/v1/oauth2/token", want: false},
+		{method: http.MethodGet, path: "/v1/instances", want: false},
+		{method: http.MethodPut, path: "/v1/volumes", want: false},
+		{method: http.MethodDelete, path: "/v1/ssh-keys/key-id", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if got := isCreateRequest(req); got != tt.want {
+				t.Errorf("isCreateRequest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -6,11 +6,14 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -19,6 +22,17 @@ import (
 	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda"
 )
 
+// Container deployment statuses reported by the status endpoint
+const (
+	deploymentStatusHealthy      = "healthy"
+	deploymentStatusPaused       = "paused"
+	deploymentStatusQuotaReached = "quota_reached"
+)
+
+// deploymentRolloutGracePeriod is how long a deployment must stay healthy after an update
+// before it is considered rolled out when no rollout status was seen
+const deploymentRolloutGracePeriod = time.Minute
+
 var _ resource.Resource = &ContainerResource{}
 var _ resource.ResourceWithImportState = &ContainerResource{}
 
@@ -31,14 +45,17 @@ type ContainerResource struct {
 }
 
 type ContainerResourceModel struct {
-	Name                      types.String `tfsdk:"name"`
-	IsSpot                    types.Bool   `tfsdk:"is_spot"`
-	Compute                   types.Object `tfsdk:"compute"`
-	Scaling                   types.Object `tfsdk:"scaling"`
-	ContainerRegistrySettings types.Object `tfsdk:"container_registry_settings"`
-	Containers                types.List   `tfsdk:"containers"`
-	EndpointBaseURL           types.String `tfsdk:"endpoint_base_url"`
-	CreatedAt                 types.String `tfsdk:"created_at"`
+	Name                      types.String   `tfsdk:"name"`
+	IsSpot                    types.Bool     `tfsdk:"is_spot"`
+	Compute                   types.Object   `tfsdk:"compute"`
+	Scaling                   types.Object   `tfsdk:"scaling"`
+	ContainerRegistrySettings types.Object   `tfsdk:"container_registry_settings"`
+	Containers                types.List     `tfsdk:"containers"`
+	EndpointBaseURL           types.String   `tfsdk:"endpoint_base_url"`
+	CreatedAt                 types.String   `tfsdk:"created_at"`
+	SensitiveEnvWOVersion     types.Int64    `tfsdk:"sensitive_env_wo_version"`
+	DeletionProtection        types.Bool     `tfsdk:"deletion_protection"`
+	Timeouts                  timeouts.Value `tfsdk:"timeouts"`
 }
 
 type ComputeModel struct {
@@ -76,6 +93,7 @@ type ContainerModel struct {
 	Healthcheck         types.Object `tfsdk:"healthcheck"`
 	EntrypointOverrides types.Object `tfsdk:"entrypoint_overrides"`
 	Env                 types.List   `tfsdk:"env"`
+	SensitiveEnv        types.List   `tfsdk:"sensitive_env"`
 	VolumeMounts        types.List   `tfsdk:"volume_mounts"`
 }
 
@@ -125,6 +143,9 @@ func (r *ContainerResource) Schema(ctx context.Context, req resource.SchemaReque
 				MarkdownDescription: "Whether to use spot instances (defaults to false)",
 				Optional:            true,
 				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"compute": schema.SingleNestedAttribute{
 				MarkdownDescription: "Compute resources for the deployment",
@@ -157,7 +178,7 @@ func (r *ContainerResource) Schema(ctx context.Context, req resource.SchemaReque
 						Required:            true,
 					},
 					"deadline_seconds": schema.Int64Attribute{
-						MarkdownDescription: "Request deadline in seconds",
+						MarkdownDescription: "Request deadline in seconds. The container deployment API has no request deadline, so this value is only kept in the state",
 						Optional:            true,
 					},
 					"concurrent_requests_per_replica": schema.Int64Attribute{
@@ -200,16 +221,25 @@ func (r *ContainerResource) Schema(ctx context.Context, req resource.SchemaReque
 				MarkdownDescription: "Container registry authentication settings",
 				Optional:            true,
 				Computed:            true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
 				Attributes: map[string]schema.Attribute{
 					"is_private": schema.StringAttribute{
 						MarkdownDescription: "Whether the registry is private ('true' or 'false')",
 						Optional:            true,
 						Computed:            true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
 					},
 					"credentials": schema.StringAttribute{
 						MarkdownDescription: "Name of the registry credentials resource",
 						Optional:            true,
 						Computed:            true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
 					},
 				},
 			},
@@ -284,6 +314,38 @@ func (r *ContainerResource) Schema(ctx context.Context, req resource.SchemaReque
 								},
 							},
 						},
+						"sensitive_env": schema.ListNestedAttribute{
+							MarkdownDescription: "Environment variables with sensitive values, such as API keys. They are merged with `env` and redacted in plan output. " +
+								"Their values are never read back from the API, but values set in `value_or_reference_to_secret` are stored in the state; " +
+								"set `value_wo` instead to keep them out of the state.",
+							Optional:  true,
+							Sensitive: true,
+							NestedObject: schema.NestedAttributeObject{
+								Validators: []validator.Object{
+									sensitiveEnvValueValidator{},
+								},
+								Attributes: map[string]schema.Attribute{
+									"type": schema.StringAttribute{
+										MarkdownDescription: "Type of environment variable ('plain' or 'secret')",
+										Required:            true,
+									},
+									"name": schema.StringAttribute{
+										MarkdownDescription: "Name of the environment variable",
+										Required:            true,
+									},
+									"value_or_reference_to_secret": schema.StringAttribute{
+										MarkdownDescription: "Value for plain env vars or secret name for secret env vars, stored in the state. Conflicts with `value_wo`.",
+										Optional:            true,
+									},
+									"value_wo": schema.StringAttribute{
+										MarkdownDescription: "Write-only variant of `value_or_reference_to_secret`, which is never stored in the state. " +
+											"Requires Terraform 1.11 or later. Change `sensitive_env_wo_version` to apply a new value.",
+										Optional:  true,
+										WriteOnly: true,
+									},
+								},
+							},
+						},
 						"volume_mounts": schema.ListNestedAttribute{
 							MarkdownDescription: "Volume mounts for the container",
 							Optional:            true,
@@ -321,11 +383,27 @@ func (r *ContainerResource) Schema(ctx context.Context, req resource.SchemaReque
 			"endpoint_base_url": schema.StringAttribute{
 				MarkdownDescription: "Base URL for the deployment endpoint",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"created_at": schema.StringAttribute{
 				MarkdownDescription: "Timestamp when the deployment was created",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"sensitive_env_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of the write-only `value_wo` values in `sensitive_env`. Terraform cannot detect changes to write-only values, " +
+					"so change this to send the current values to the API.",
+				Optional: true,
 			},
+			"deletion_protection": deletionProtectionAttribute("container deployment"),
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
 		},
 	}
 }
@@ -358,6 +436,254 @@ func (r *ContainerResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultDeploymentCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	writeOnlyEnv := configWriteOnlyEnv(ctx, req.Config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createReq := r.expandDeploymentRequest(ctx, &data, writeOnlyEnv, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deployment, err := r.client.ContainerDeployments.CreateDeployment(ctx, createReq)
+	if err != nil {
+		deployment = r.adoptDeployment(ctx, &data, err, &resp.Diagnostics)
+		if deployment == nil {
+			return
+		}
+	}
+
+	// Flatten API response, merging with plan to preserve fields the API doesn't return
+	planContainers := data.Containers
+	r.flattenDeploymentToModel(ctx, deployment, &data, &resp.Diagnostics)
+	// Merge API response with plan to preserve fields the API doesn't echo back
+	r.mergeContainersFromPlan(ctx, planContainers, &data, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ContainerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ContainerResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	deployment, err := r.client.ContainerDeployments.GetDeploymentByName(ctx, data.Name.ValueString())
+	if err != nil {
+		if isNotFoundError(err) {
+			// The container deployment was deleted outside of Terraform, so plan to recreate it
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read container deployment, got error: %s", err))
+		return
+	}
+
+	// Also fetch scaling configuration
+	scalingConfig, err := r.client.ContainerDeployments.GetDeploymentScaling(ctx, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read scaling configuration, got error: %s", err))
+		return
+	}
+
+	// Preserve container configuration from prior state
+	// The API doesn't return all fields (like volume_id for non-shared volumes)
+	priorContainers := data.Containers
+	r.flattenDeploymentToModel(ctx, deployment, &data, &resp.Diagnostics)
+	r.flattenScalingToModel(ctx, scalingConfig, &data, &resp.Diagnostics)
+	// Merge API response with prior state to preserve fields the API doesn't return
+	r.mergeContainersFromPlan(ctx, priorContainers, &data, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ContainerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ContainerResourceModel
+	var state ContainerResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settingsOnly, err := planChangesOnly(req.Plan, req.State, "deletion_protection", "timeouts")
+	if err != nil {
+		resp.Diagnostics.AddError("Internal Error", fmt.Sprintf("Unable to compare plan with state, got error: %s", err))
+		return
+	}
+
+	if settingsOnly {
+		// Only provider-side settings changed, which are not sent to the API
+		state.DeletionProtection = data.DeletionProtection
+		state.Timeouts = data.Timeouts
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultDeploymentUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	writeOnlyEnv := configWriteOnlyEnv(ctx, req.Config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateReq := r.expandDeploymentRequest(ctx, &data, writeOnlyEnv, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deploymentName := data.Name.ValueString()
+
+	// The deployment and its scaling options are updated through separate endpoints. Changing
+	// the deployment rolls out a new revision, while scaling changes apply to the running one.
+	deploymentChanged := !data.IsSpot.Equal(state.IsSpot) ||
+		!data.Compute.Equal(state.Compute) ||
+		!data.ContainerRegistrySettings.Equal(state.ContainerRegistrySettings) ||
+		!data.Containers.Equal(state.Containers) ||
+		!data.SensitiveEnvWOVersion.Equal(state.SensitiveEnvWOVersion)
+
+	if deploymentChanged {
+		_, err := r.client.ContainerDeployments.UpdateDeployment(ctx, deploymentName, &verda.UpdateDeploymentRequest{
+			IsSpot:                    &updateReq.IsSpot,
+			Compute:                   &updateReq.Compute,
+			ContainerRegistrySettings: &updateReq.ContainerRegistrySettings,
+			Containers:                updateReq.Containers,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update container deployment, got error: %s", err))
+			return
+		}
+	}
+
+	if !data.Scaling.Equal(state.Scaling) {
+		scaling := updateReq.Scaling
+		_, err := r.client.ContainerDeployments.UpdateDeploymentScaling(ctx, deploymentName, &verda.UpdateScalingOptionsRequest{
+			MinReplicaCount:              &scaling.MinReplicaCount,
+			MaxReplicaCount:              &scaling.MaxReplicaCount,
+			ScaleDownPolicy:              scaling.ScaleDownPolicy,
+			ScaleUpPolicy:                scaling.ScaleUpPolicy,
+			QueueMessageTTLSeconds:       &scaling.QueueMessageTTLSeconds,
+			ConcurrentRequestsPerReplica: &scaling.ConcurrentRequestsPerReplica,
+			ScalingTriggers:              scaling.ScalingTriggers,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update container deployment scaling, got error: %s", err))
+			return
+		}
+	}
+
+	if deploymentChanged {
+		if err := r.waitForDeploymentHealthy(ctx, deploymentName); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Container deployment %s did not become healthy after the update: %s", deploymentName, err))
+			return
+		}
+	}
+
+	deployment, err := r.client.ContainerDeployments.GetDeploymentByName(ctx, deploymentName)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read container deployment after update, got error: %s", err))
+		return
+	}
+
+	// Read the scaling back as well, so values filled in by the API match the next refresh
+	scalingConfig, err := r.client.ContainerDeployments.GetDeploymentScaling(ctx, deploymentName)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read scaling configuration after update, got error: %s", err))
+		return
+	}
+
+	// Flatten API response, merging with plan to preserve fields the API doesn't return
+	planContainers := data.Containers
+	r.flattenDeploymentToModel(ctx, deployment, &data, &resp.Diagnostics)
+	r.flattenScalingToModel(ctx, scalingConfig, &data, &resp.Diagnostics)
+	r.mergeContainersFromPlan(ctx, planContainers, &data, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ContainerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ContainerResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if checkDeletionProtection(data.DeletionProtection, "container deployment", data.Name.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeploymentDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	// Initiate deletion (ignore timeout errors as we'll poll instead)
+	err := r.client.ContainerDeployments.DeleteDeployment(ctx, data.Name.ValueString(), deadlineMilliseconds(ctx, 60000))
+	if isNotFoundError(err) {
+		// Already deleted outside of Terraform
+		return
+	}
+	if err != nil && !isTimeoutError(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete container deployment, got error: %s", err))
+		return
+	}
+
+	// Poll until deployment is gone (404) or the delete timeout expires
+	if err := r.waitForDeletionComplete(ctx, data.Name.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Timeout waiting for container deployment deletion: %s", err))
+		return
+	}
+}
+
+func (r *ContainerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
+
+// expandDeploymentRequest builds the deployment request from the plan. It is used for
+// both creating and updating the deployment.
+func (r *ContainerResource) expandDeploymentRequest(ctx context.Context, data *ContainerResourceModel, writeOnlyEnv map[int]map[string]string, diagnostics *diag.Diagnostics) *verda.CreateDeploymentRequest {
 	createReq := &verda.CreateDeploymentRequest{
 		Name:   data.Name.ValueString(),
 		IsSpot: data.IsSpot.ValueBool(),
@@ -365,9 +691,9 @@ func (r *ContainerResource) Create(ctx context.Context, req resource.CreateReque
 
 	// Parse compute
 	var compute ComputeModel
-	resp.Diagnostics.Append(data.Compute.As(ctx, &compute, basetypes.ObjectAsOptions{})...)
-	if resp.Diagnostics.HasError() {
-		return
+	diagnostics.Append(data.Compute.As(ctx, &compute, basetypes.ObjectAsOptions{})...)
+	if diagnostics.HasError() {
+		return nil
 	}
 
 	createReq.Compute = verda.ContainerCompute{
@@ -377,9 +703,9 @@ func (r *ContainerResource) Create(ctx context.Context, req resource.CreateReque
 
 	// Parse scaling
 	var scaling ScalingModel
-	resp.Diagnostics.Append(data.Scaling.As(ctx, &scaling, basetypes.ObjectAsOptions{})...)
-	if resp.Diagnostics.HasError() {
-		return
+	diagnostics.Append(data.Scaling.As(ctx, &scaling, basetypes.ObjectAsOptions{})...)
+	if diagnostics.HasError() {
+		return nil
 	}
 
 	scalingOptions := verda.ContainerScalingOptions{
@@ -391,9 +717,9 @@ func (r *ContainerResource) Create(ctx context.Context, req resource.CreateReque
 
 	// Parse scale down policy
 	var scaleDownPolicy ScalingPolicyModel
-	resp.Diagnostics.Append(scaling.ScaleDownPolicy.As(ctx, &scaleDownPolicy, basetypes.ObjectAsOptions{})...)
-	if resp.Diagnostics.HasError() {
-		return
+	diagnostics.Append(scaling.ScaleDownPolicy.As(ctx, &scaleDownPolicy, basetypes.ObjectAsOptions{})...)
+	if diagnostics.HasError() {
+		return nil
 	}
 	scalingOptions.ScaleDownPolicy = &verda.ScalingPolicy{
 		DelaySeconds: int(scaleDownPolicy.DelaySeconds.ValueInt64()),
@@ -401,9 +727,9 @@ func (r *ContainerResource) Create(ctx context.Context, req resource.CreateReque
 
 	// Parse scale up policy
 	var scaleUpPolicy ScalingPolicyModel
-	resp.Diagnostics.Append(scaling.ScaleUpPolicy.As(ctx, &scaleUpPolicy, basetypes.ObjectAsOptions{})...)
-	if resp.Diagnostics.HasError() {
-		return
+	diagnostics.Append(scaling.ScaleUpPolicy.As(ctx, &scaleUpPolicy, basetypes.ObjectAsOptions{})...)
+	if diagnostics.HasError() {
+		return nil
 	}
 	scalingOptions.ScaleUpPolicy = &verda.ScalingPolicy{
 		DelaySeconds: int(scaleUpPolicy.DelaySeconds.ValueInt64()),
@@ -411,9 +737,9 @@ func (r *ContainerResource) Create(ctx context.Context, req resource.CreateReque
 
 	// Parse queue load trigger
 	var queueLoad QueueLoadTriggerModel
-	resp.Diagnostics.Append(scaling.QueueLoad.As(ctx, &queueLoad, basetypes.ObjectAsOptions{})...)
-	if resp.Diagnostics.HasError() {
-		return
+	diagnostics.Append(scaling.QueueLoad.As(ctx, &queueLoad, basetypes.ObjectAsOptions{})...)
+	if diagnostics.HasError() {
+		return nil
 	}
 	scalingOptions.ScalingTriggers = &verda.ScalingTriggers{
 		QueueLoad: &verda.QueueLoadTrigger{
@@ -426,9 +752,9 @@ func (r *ContainerResource) Create(ctx context.Context, req resource.CreateReque
 	// Parse container registry settings if provided
 	if !data.ContainerRegistrySettings.IsNull() && !data.ContainerRegistrySettings.IsUnknown() {
 		var registrySettings RegistrySettingsModel
-		resp.Diagnostics.Append(data.ContainerRegistrySettings.As(ctx, &registrySettings, basetypes.ObjectAsOptions{})...)
-		if resp.Diagnostics.HasError() {
-			return
+		diagnostics.Append(data.ContainerRegistrySettings.As(ctx, &registrySettings, basetypes.ObjectAsOptions{})...)
+		if diagnostics.HasError() {
+			return nil
 		}
 
 		isPrivate := registrySettings.IsPrivate.ValueString() == "true"
@@ -450,13 +776,13 @@ func (r *ContainerResource) Create(ctx context.Context, req resource.CreateReque
 
 	// Parse containers
 	var containers []ContainerModel
-	resp.Diagnostics.Append(data.Containers.ElementsAs(ctx, &containers, false)...)
-	if resp.Diagnostics.HasError() {
-		return
+	diagnostics.Append(data.Containers.ElementsAs(ctx, &containers, false)...)
+	if diagnostics.HasError() {
+		return nil
 	}
 
 	var deploymentContainers []verda.CreateDeploymentContainer
-	for _, container := range containers {
+	for i, container := range containers {
 		deploymentContainer := verda.CreateDeploymentContainer{
 			Image:       container.Image.ValueString(),
 			ExposedPort: int(container.ExposedPort.ValueInt64()),
@@ -465,9 +791,9 @@ func (r *ContainerResource) Create(ctx context.Context, req resource.CreateReque
 		// Parse healthcheck if provided
 		if !container.Healthcheck.IsNull() {
 			var healthcheck HealthcheckModel
-			resp.Diagnostics.Append(container.Healthcheck.As(ctx, &healthcheck, basetypes.ObjectAsOptions{})...)
-			if resp.Diagnostics.HasError() {
-				return
+			diagnostics.Append(container.Healthcheck.As(ctx, &healthcheck, basetypes.ObjectAsOptions{})...)
+			if diagnostics.HasError() {
+				return nil
 			}
 
 			enabled := healthcheck.Enabled.ValueString() == "true"
@@ -493,9 +819,9 @@ func (r *ContainerResource) Create(ctx context.Context, req resource.CreateReque
 		// Parse entrypoint overrides if provided
 		if !container.EntrypointOverrides.IsNull() && !container.EntrypointOverrides.IsUnknown() {
 			var entrypointOverrides EntrypointOverridesModel
-			resp.Diagnostics.Append(container.EntrypointOverrides.As(ctx, &entrypointOverrides, basetypes.ObjectAsOptions{})...)
-			if resp.Diagnostics.HasError() {
-				return
+			diagnostics.Append(container.EntrypointOverrides.As(ctx, &entrypointOverrides, basetypes.ObjectAsOptions{})...)
+			if diagnostics.HasError() {
+				return nil
 			}
 
 			overrides := &verda.ContainerEntrypointOverrides{
@@ -504,18 +830,18 @@ func (r *ContainerResource) Create(ctx context.Context, req resource.CreateReque
 
 			if !entrypointOverrides.Entrypoint.IsNull() && !entrypointOverrides.Entrypoint.IsUnknown() {
 				var entrypoint []string
-				resp.Diagnostics.Append(entrypointOverrides.Entrypoint.ElementsAs(ctx, &entrypoint, false)...)
-				if resp.Diagnostics.HasError() {
-					return
+				diagnostics.Append(entrypointOverrides.Entrypoint.ElementsAs(ctx, &entrypoint, false)...)
+				if diagnostics.HasError() {
+					return nil
 				}
 				overrides.Entrypoint = entrypoint
 			}
 
 			if !entrypointOverrides.Cmd.IsNull() && !entrypointOverrides.Cmd.IsUnknown() {
 				var cmd []string
-				resp.Diagnostics.Append(entrypointOverrides.Cmd.ElementsAs(ctx, &cmd, false)...)
-				if resp.Diagnostics.HasError() {
-					return
+				diagnostics.Append(entrypointOverrides.Cmd.ElementsAs(ctx, &cmd, false)...)
+				if diagnostics.HasError() {
+					return nil
 				}
 				overrides.Cmd = cmd
 			}
@@ -526,9 +852,9 @@ func (r *ContainerResource) Create(ctx context.Context, req resource.CreateReque
 		// Parse environment variables if provided
 		if !container.Env.IsNull() {
 			var envVars []EnvVarModel
-			resp.Diagnostics.Append(container.Env.ElementsAs(ctx, &envVars, false)...)
-			if resp.Diagnostics.HasError() {
-				return
+			diagnostics.Append(container.Env.ElementsAs(ctx, &envVars, false)...)
+			if diagnostics.HasError() {
+				return nil
 			}
 
 			var containerEnvVars []verda.ContainerEnvVar
@@ -542,12 +868,18 @@ func (r *ContainerResource) Create(ctx context.Context, req resource.CreateReque
 			deploymentContainer.Env = containerEnvVars
 		}
 
+		// Sensitive environment variables are sent together with the other ones
+		deploymentContainer.Env = append(deploymentContainer.Env, expandSensitiveEnv(ctx, container, writeOnlyEnv[i], diagnostics)...)
+		if diagnostics.HasError() {
+			return nil
+		}
+
 		// Parse volume mounts if provided
 		if !container.VolumeMounts.IsNull() {
 			var volumeMounts []VolumeMountModel
-			resp.Diagnostics.Append(container.VolumeMounts.ElementsAs(ctx, &volumeMounts, false)...)
-			if resp.Diagnostics.HasError() {
-				return
+			diagnostics.Append(container.VolumeMounts.ElementsAs(ctx, &volumeMounts, false)...)
+			if diagnostics.HasError() {
+				return nil
 			}
 
 			var containerVolumeMounts []verda.ContainerVolumeMount
@@ -579,99 +911,69 @@ func (r *ContainerResource) Create(ctx context.Context, req resource.CreateReque
 
 	createReq.Containers = deploymentContainers
 
-	deployment, err := r.client.ContainerDeployments.CreateDeployment(ctx, createReq)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create container deployment, got error: %s", err))
-		return
-	}
-
-	// Flatten API response, merging with plan to preserve fields the API doesn't return
-	planContainers := data.Containers
-	r.flattenDeploymentToModel(ctx, deployment, &data, &resp.Diagnostics)
-	// Merge API response with plan to preserve fields the API doesn't echo back
-	r.mergeContainersFromPlan(ctx, planContainers, &data, &resp.Diagnostics)
-
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	return createReq
 }
 
-func (r *ContainerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data ContainerResourceModel
-
-	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+// adoptDeployment looks for a deployment left behind by an ambiguous create failure, such as
+// a timeout, a dropped connection or a server error. It returns the deployment if its full
+// configuration matches the plan, or adds an error and returns nil.
+func (r *ContainerResource) adoptDeployment(ctx context.Context, data *ContainerResourceModel, createErr error, diagnostics *diag.Diagnostics) *verda.ContainerDeployment {
+	createError := fmt.Sprintf("Unable to create container deployment, got error: %s", createErr)
 
-	if resp.Diagnostics.HasError() {
-		return
+	if !isAmbiguousCreateError(createErr) {
+		diagnostics.AddError("Client Error", createError)
+		return nil
 	}
 
-	deployment, err := r.client.ContainerDeployments.GetDeploymentByName(ctx, data.Name.ValueString())
+	ctx, cancel := ambiguousCreateLookupContext(ctx)
+	defer cancel()
+
+	deploymentName := data.Name.ValueString()
+	deployment, err := r.client.ContainerDeployments.GetDeploymentByName(ctx, deploymentName)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read container deployment, got error: %s", err))
-		return
+		// Nothing was created, or it cannot be checked, so report the original error
+		diagnostics.AddError("Client Error", createError)
+		return nil
 	}
 
-	// Also fetch scaling configuration
-	scalingConfig, err := r.client.ContainerDeployments.GetDeploymentScaling(ctx, data.Name.ValueString())
+	scalingConfig, err := r.client.ContainerDeployments.GetDeploymentScaling(ctx, deploymentName)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read scaling configuration, got error: %s", err))
-		return
+		diagnostics.AddError("Client Error", createError)
+		return nil
 	}
 
-	// Preserve container configuration from prior state
-	// The API doesn't return all fields (like volume_id for non-shared volumes)
-	priorContainers := data.Containers
-	r.flattenDeploymentToModel(ctx, deployment, &data, &resp.Diagnostics)
-	r.flattenScalingToModel(ctx, scalingConfig, &data, &resp.Diagnostics)
-	// Merge API response with prior state to preserve fields the API doesn't return
-	r.mergeContainersFromPlan(ctx, priorContainers, &data, &resp.Diagnostics)
-
-	if resp.Diagnostics.HasError() {
-		return
+	// Read the existing deployment the same way as a refresh would, so it can be compared with the plan.
+	// Fields the API does not return, such as sensitive_env values, cannot be compared and are taken from the plan.
+	existing := *data
+	r.flattenDeploymentToModel(ctx, deployment, &existing, diagnostics)
+	r.flattenScalingToModel(ctx, scalingConfig, &existing, diagnostics)
+	r.mergeContainersFromPlan(ctx, data.Containers, &existing, diagnostics)
+	if diagnostics.HasError() {
+		return nil
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-}
-
-func (r *ContainerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data ContainerResourceModel
-
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
-
-	if resp.Diagnostics.HasError() {
-		return
+	matches := len(deployment.Containers) == len(data.Containers.Elements()) &&
+		existing.IsSpot.Equal(data.IsSpot) &&
+		existing.Compute.Equal(data.Compute) &&
+		existing.ContainerRegistrySettings.Equal(data.ContainerRegistrySettings) &&
+		existing.Containers.Equal(data.Containers) &&
+		existing.Scaling.Equal(data.Scaling)
+
+	if !matches {
+		diagnostics.AddError(
+			"Container Deployment Already Exists",
+			fmt.Sprintf("%s\n\nA container deployment named %s already exists, but its configuration differs from the plan. "+
+				"Import it with 'terraform import' or choose another name.", createError, deploymentName),
+		)
+		return nil
 	}
 
-	// Container deployments cannot be updated, only deleted and recreated
-	resp.Diagnostics.AddError(
-		"Update Not Supported",
-		"Container deployments cannot be updated. Please delete and recreate the resource with new values.",
+	diagnostics.AddWarning(
+		"Adopted Existing Container Deployment",
+		fmt.Sprintf("Creating container deployment %s failed with: %s\n\nA deployment with this name and the planned configuration exists, "+
+			"probably created by that request, so it was adopted into the state.", deploymentName, createErr),
 	)
-}
-
-func (r *ContainerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data ContainerResourceModel
-
-	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
-
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	// Initiate deletion (ignore timeout errors as we'll poll instead)
-	err := r.client.ContainerDeployments.DeleteDeployment(ctx, data.Name.ValueString(), 60000)
-	if err != nil && !isTimeoutError(err) {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete container deployment, got error: %s", err))
-		return
-	}
-
-	// Poll until deployment is gone (404) with 5 minute timeout
-	if err := r.waitForDeletionComplete(ctx, data.Name.ValueString(), 300); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Timeout waiting for container deployment deletion: %s", err))
-		return
-	}
-}
-
-func (r *ContainerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	return deployment
 }
 
 func isTimeoutError(err error) bool {
@@ -682,31 +984,61 @@ func isTimeoutError(err error) bool {
 	return strings.Contains(errStr, "504") || strings.Contains(strings.ToLower(errStr), "timeout")
 }
 
-func (r *ContainerResource) waitForDeletionComplete(ctx context.Context, deploymentName string, timeoutSeconds int) error {
-	deadline := time.Now().Add(time.Duration(timeoutSeconds) * time.Second)
+func (r *ContainerResource) waitForDeletionComplete(ctx context.Context, deploymentName string) error {
+	for {
+		// Try to get the deployment
+		_, err := r.client.ContainerDeployments.GetDeploymentByName(ctx, deploymentName)
+		// Deployment not found = successfully deleted. For other errors, continue polling
+		// (deployment might be in transition)
+		if isNotFoundError(err) {
+			return nil
+		}
 
-	for time.Now().Before(deadline) {
-		// Check if context was cancelled
-		if ctx.Err() != nil {
-			return fmt.Errorf("context cancelled: %w", ctx.Err())
+		// Wait 10 seconds before trying again, unless the context deadline expires first
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("deployment %s still exists: %w", deploymentName, ctx.Err())
+		case <-time.After(10 * time.Second):
 		}
+	}
+}
 
-		// Try to get the deployment
-		_, err := r.client.ContainerDeployments.GetDeploymentByName(ctx, deploymentName)
+// waitForDeploymentHealthy polls the deployment status until the new revision is healthy. The
+// status endpoint does not report revisions, so right after an update it may still report the
+// previous revision as healthy. Healthy is therefore only accepted once the status has changed
+// since the update, showing that the rollout started, or once it has stayed healthy for
+// deploymentRolloutGracePeriod, in case the rollout finished between two polls.
+func (r *ContainerResource) waitForDeploymentHealthy(ctx context.Context, deploymentName string) error {
+	start := time.Now()
+	rolloutSeen := false
+
+	for {
+		status, err := r.client.ContainerDeployments.GetDeploymentStatus(ctx, deploymentName)
 		if err != nil {
-			// Check if it's a 404 error (deployment not found = successfully deleted)
-			errStr := err.Error()
-			if strings.Contains(errStr, "404") || strings.Contains(strings.ToLower(errStr), "not found") {
+			return err
+		}
+
+		switch status.Status {
+		case deploymentStatusHealthy:
+			if rolloutSeen || time.Since(start) >= deploymentRolloutGracePeriod {
 				return nil
 			}
-			// For other errors, continue polling (deployment might be in transition)
+		case deploymentStatusPaused:
+			// Paused deployments run no replicas, so there is no rollout to wait for
+			return nil
+		case deploymentStatusQuotaReached:
+			return fmt.Errorf("deployment has status %s", status.Status)
+		default:
+			rolloutSeen = true
 		}
 
-		// Wait 10 seconds before trying again
-		time.Sleep(10 * time.Second)
+		// Wait 10 seconds before trying again, unless the update timeout expires first
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("deployment still has status %s: %w", status.Status, ctx.Err())
+		case <-time.After(10 * time.Second):
+		}
 	}
-
-	return fmt.Errorf("timeout after %d seconds waiting for deployment deletion", timeoutSeconds)
 }
 
 func (r *ContainerResource) flattenDeploymentToModel(ctx context.Context, deployment *verda.ContainerDeployment, data *ContainerResourceModel, diagnostics *diag.Diagnostics) {
@@ -801,6 +1133,9 @@ func (r *ContainerResource) mergeContainersFromPlan(ctx context.Context, planCon
 		mergedContainer := apiContainer
 		mergedContainer.VolumeMounts = planContainer.VolumeMounts
 
+		// Keep sensitive values out of env and take them from the plan, never from the API
+		mergedContainer.Env, mergedContainer.SensitiveEnv = separateSensitiveEnv(ctx, apiContainer.Env, planContainer.SensitiveEnv, diagnostics)
+
 		// Also preserve entrypoint_overrides from plan if API didn't return it
 		if (apiContainer.EntrypointOverrides.IsNull() || apiContainer.EntrypointOverrides.IsUnknown()) &&
 			!planContainer.EntrypointOverrides.IsNull() {
@@ -834,6 +1169,11 @@ func (r *ContainerResource) mergeContainersFromPlan(ctx context.Context, planCon
 					},
 				},
 			},
+			"sensitive_env": types.ListType{
+				ElemType: types.ObjectType{
+					AttrTypes: sensitiveEnvVarAttrTypes,
+				},
+			},
 			"volume_mounts": types.ListType{
 				ElemType: types.ObjectType{
 					AttrTypes: map[string]attr.Type{
@@ -853,6 +1193,7 @@ func (r *ContainerResource) mergeContainersFromPlan(ctx context.Context, planCon
 			"healthcheck":          mergedContainer.Healthcheck,
 			"entrypoint_overrides": mergedContainer.EntrypointOverrides,
 			"env":                  mergedContainer.Env,
+			"sensitive_env":        mergedContainer.SensitiveEnv,
 			"volume_mounts":        mergedContainer.VolumeMounts,
 		}
 
@@ -890,6 +1231,11 @@ func (r *ContainerResource) mergeContainersFromPlan(ctx context.Context, planCon
 						},
 					},
 				},
+				"sensitive_env": types.ListType{
+					ElemType: types.ObjectType{
+						AttrTypes: sensitiveEnvVarAttrTypes,
+					},
+				},
 				"volume_mounts": types.ListType{
 					ElemType: types.ObjectType{
 						AttrTypes: map[string]attr.Type{
@@ -1126,6 +1472,11 @@ func (r *ContainerResource) flattenContainersToModel(ctx context.Context, contai
 					},
 				},
 			},
+			"sensitive_env": types.ListType{
+				ElemType: types.ObjectType{
+					AttrTypes: sensitiveEnvVarAttrTypes,
+				},
+			},
 			"volume_mounts": types.ListType{
 				ElemType: types.ObjectType{
 					AttrTypes: map[string]attr.Type{
@@ -1145,6 +1496,7 @@ func (r *ContainerResource) flattenContainersToModel(ctx context.Context, contai
 			"healthcheck":          healthcheckObj,
 			"entrypoint_overrides": entrypointOverridesObj,
 			"env":                  envList,
+			"sensitive_env":        types.ListNull(types.ObjectType{AttrTypes: sensitiveEnvVarAttrTypes}),
 			"volume_mounts":        volumeMountsList,
 		}
 
@@ -1182,6 +1534,11 @@ func (r *ContainerResource) flattenContainersToModel(ctx context.Context, contai
 						},
 					},
 				},
+				"sensitive_env": types.ListType{
+					ElemType: types.ObjectType{
+						AttrTypes: sensitiveEnvVarAttrTypes,
+					},
+				},
 				"volume_mounts": types.ListType{
 					ElemType: types.ObjectType{
 						AttrTypes: map[string]attr.Type{
@@ -1232,6 +1589,14 @@ func (r *ContainerResource) flattenScalingToModel(ctx context.Context, scalingCo
 	)
 	diagnostics.Append(diags...)
 
+	// The API has no request deadline, so deadline_seconds is kept from the plan or state
+	deadlineSeconds := types.Int64Null()
+	if !data.Scaling.IsNull() && !data.Scaling.IsUnknown() {
+		if v, ok := data.Scaling.Attributes()["deadline_seconds"].(types.Int64); ok {
+			deadlineSeconds = v
+		}
+	}
+
 	scalingObj, diags := types.ObjectValue(
 		map[string]attr.Type{
 			"min_replica_count":               types.Int64Type,
@@ -1259,7 +1624,7 @@ func (r *ContainerResource) flattenScalingToModel(ctx context.Context, scalingCo
 			"min_replica_count":               types.Int64Value(int64(scalingConfig.MinReplicaCount)),
 			"max_replica_count":               types.Int64Value(int64(scalingConfig.MaxReplicaCount)),
 			"queue_message_ttl_seconds":       types.Int64Value(int64(scalingConfig.QueueMessageTTLSeconds)),
-			"deadline_seconds":                types.Int64Value(int64(scalingConfig.QueueMessageTTLSeconds)),
+			"deadline_seconds":                deadlineSeconds,
 			"concurrent_requests_per_replica": types.Int64Value(int64(scalingConfig.ConcurrentRequestsPerReplica)),
 			"scale_down_policy":               scaleDownPolicyObj,
 			"scale_up_policy":                 scaleUpPolicyObj,
@@ -0,0 +1,330 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda"
+)
+
+var _ resource.Resource = &ContainerFileSecretResource{}
+var _ resource.ResourceWithImportState = &ContainerFileSecretResource{}
+
+func NewContainerFileSecretResource() resource.Resource {
+	return &ContainerFileSecretResource{}
+}
+
+type ContainerFileSecretResource struct {
+	client *verda.Client
+}
+
+type ContainerFileSecretResourceModel struct {
+	Name       types.String   `tfsdk:"name"`
+	Files      types.Map      `tfsdk:"files"`
+	FileNames  types.List     `tfsdk:"file_names"`
+	SecretType types.String   `tfsdk:"secret_type"`
+	CreatedAt  types.String   `tfsdk:"created_at"`
+	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *ContainerFileSecretResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_container_file_secret"
+}
+
+func (r *ContainerFileSecretResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a file secret that container deployments and serverless jobs can mount as a volume",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the file secret",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"files": schema.MapAttribute{
+				MarkdownDescription: "Files in the secret, keyed by file name, with the file content as value",
+				ElementType:         types.StringType,
+				Required:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplaceIf(
+						filesRequireReplaceUnlessImported,
+						"Changing the files recreates the file secret, unless the file secret was imported and its files are not known yet.",
+						"Changing the files recreates the file secret, unless the file secret was imported and its files are not known yet.",
+					),
+				},
+			},
+			"file_names": schema.ListAttribute{
+				MarkdownDescription: "Names of the files in the secret as reported by the API",
+				ElementType:         types.StringType,
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"secret_type": schema.StringAttribute{
+				MarkdownDescription: "Type of the secret as reported by the API",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "Timestamp when the file secret was created",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
+	}
+}
+
+func (r *ContainerFileSecretResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*verda.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *verda.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ContainerFileSecretResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ContainerFileSecretResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	var files map[string]string
+	resp.Diagnostics.Append(data.Files.ElementsAs(ctx, &files, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createReq := &verda.CreateFileSecretRequest{
+		Name: data.Name.ValueString(),
+	}
+
+	// Send the files in a stable order, the API expects the content base64 encoded
+	for _, fileName := range slices.Sorted(maps.Keys(files)) {
+		createReq.Files = append(createReq.Files, verda.FileSecretFile{
+			Name:          fileName,
+			Base64Content: base64.StdEncoding.EncodeToString([]byte(files[fileName])),
+		})
+	}
+
+	err := r.client.ContainerDeployments.CreateFileSecret(ctx, createReq)
+	if err != nil {
+		detail := fmt.Sprintf("Unable to create file secret, got error: %s", err)
+		// The API does not return secret values, so a file secret left behind by the failed request cannot be verified and adopted
+		if isAmbiguousCreateError(err) {
+			lookupCtx, lookupCancel := ambiguousCreateLookupContext(ctx)
+			defer lookupCancel()
+			if existing, findErr := r.findFileSecret(lookupCtx, data.Name.ValueString()); findErr == nil && existing != nil {
+				detail += unverifiedCreateHint("a file secret", data.Name.ValueString())
+			}
+		}
+		resp.Diagnostics.AddError("Client Error", detail)
+		return
+	}
+
+	// The API doesn't return the created file secret, so we need to fetch it
+	secret, err := r.findFileSecret(ctx, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read file secret after creation, got error: %s", err))
+		return
+	}
+
+	if secret == nil {
+		resp.Diagnostics.AddWarning("File Secret Not Found", "Created file secret could not be found in the list")
+		data.FileNames = types.ListNull(types.StringType)
+		data.SecretType = types.StringNull()
+		data.CreatedAt = types.StringNull()
+	} else {
+		r.flattenFileSecretToModel(ctx, secret, &data, &resp.Diagnostics)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ContainerFileSecretResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ContainerFileSecretResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	secret, err := r.findFileSecret(ctx, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read file secret, got error: %s", err))
+		return
+	}
+
+	if secret == nil {
+		// The file secret was deleted outside of Terraform, so plan to recreate it
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// The API never returns the file content, so the files from the state are kept. Files
+	// that no longer exist are dropped, so the missing files show up as a change.
+	if !data.Files.IsNull() {
+		var files map[string]string
+		resp.Diagnostics.Append(data.Files.ElementsAs(ctx, &files, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		maps.DeleteFunc(files, func(fileName string, _ string) bool {
+			return !slices.Contains(secret.FileNames, fileName)
+		})
+
+		filesValue, diags := types.MapValueFrom(ctx, types.StringType, files)
+		resp.Diagnostics.Append(diags...)
+		data.Files = filesValue
+	}
+
+	r.flattenFileSecretToModel(ctx, secret, &data, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ContainerFileSecretResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ContainerFileSecretResourceModel
+	var state ContainerFileSecretResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// name and files require replacement, so only the timeouts can change here, or the files
+	// of an imported file secret, which the API does not return, are filled in from the configuration
+	state.Files = data.Files
+	state.Timeouts = data.Timeouts
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *ContainerFileSecretResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ContainerFileSecretResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.client.ContainerDeployments.DeleteFileSecret(ctx, data.Name.ValueString(), false)
+	// Already deleted outside of Terraform
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to delete file secret, got error: %s. File secrets that are still mounted by a deployment cannot be deleted.", err),
+		)
+		return
+	}
+}
+
+func (r *ContainerFileSecretResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
+
+// findFileSecret returns the file secret with the given name, or nil if it does not exist
+func (r *ContainerFileSecretResource) findFileSecret(ctx context.Context, name string) (*verda.FileSecret, error) {
+	secrets, err := r.client.ContainerDeployments.GetFileSecrets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range secrets {
+		if secrets[i].Name == name {
+			return &secrets[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *ContainerFileSecretResource) flattenFileSecretToModel(ctx context.Context, secret *verda.FileSecret, data *ContainerFileSecretResourceModel, diagnostics *diag.Diagnostics) {
+	fileNames, diags := types.ListValueFrom(ctx, types.StringType, secret.FileNames)
+	diagnostics.Append(diags...)
+
+	data.FileNames = fileNames
+	data.SecretType = types.StringValue(secret.SecretType)
+	data.CreatedAt = types.StringValue(secret.CreatedAt.Format(time.RFC3339))
+}
+
+// filesRequireReplaceUnlessImported requires replacement when the files change. Imported file
+// secrets have no files in the state, since the API never returns them, so setting them is not a change.
+func filesRequireReplaceUnlessImported(ctx context.Context, req planmodifier.MapRequest, resp *mapplanmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = !req.StateValue.IsNull()
+}
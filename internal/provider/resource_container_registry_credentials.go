@@ -3,19 +3,27 @@ package provider
 import (
 	"context"
 	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda"
 )
 
 var _ resource.Resource = &ContainerRegistryCredentialsResource{}
 var _ resource.ResourceWithImportState = &ContainerRegistryCredentialsResource{}
+var _ resource.ResourceWithValidateConfig = &ContainerRegistryCredentialsResource{}
+var _ resource.ResourceWithConfigValidators = &ContainerRegistryCredentialsResource{}
 
 func NewContainerRegistryCredentialsResource() resource.Resource {
 	return &ContainerRegistryCredentialsResource{}
@@ -26,19 +34,36 @@ type ContainerRegistryCredentialsResource struct {
 }
 
 type ContainerRegistryCredentialsResourceModel struct {
-	Name              types.String `tfsdk:"name"`
-	Type              types.String `tfsdk:"type"`
-	Username          types.String `tfsdk:"username"`
-	AccessToken       types.String `tfsdk:"access_token"`
-	ServiceAccountKey types.String `tfsdk:"service_account_key"`
-	DockerConfigJSON  types.String `tfsdk:"docker_config_json"`
-	AccessKeyID       types.String `tfsdk:"access_key_id"`
-	SecretAccessKey   types.String `tfsdk:"secret_access_key"`
-	Region            types.String `tfsdk:"region"`
-	EcrRepo           types.String `tfsdk:"ecr_repo"`
-	ScalewayDomain    types.String `tfsdk:"scaleway_domain"`
-	ScalewayUUID      types.String `tfsdk:"scaleway_uuid"`
-	CreatedAt         types.String `tfsdk:"created_at"`
+	Name              types.String   `tfsdk:"name"`
+	NamePrefix        types.String   `tfsdk:"name_prefix"`
+	Type              types.String   `tfsdk:"type"`
+	Username          types.String   `tfsdk:"username"`
+	AccessToken       types.String   `tfsdk:"access_token"`
+	ServiceAccountKey types.String   `tfsdk:"service_account_key"`
+	DockerConfigJSON  types.String   `tfsdk:"docker_config_json"`
+	AccessKeyID       types.String   `tfsdk:"access_key_id"`
+	SecretAccessKey   types.String   `tfsdk:"secret_access_key"`
+	Region            types.String   `tfsdk:"region"`
+	EcrRepo           types.String   `tfsdk:"ecr_repo"`
+	ScalewayDomain    types.String   `tfsdk:"scaleway_domain"`
+	ScalewayUUID      types.String   `tfsdk:"scaleway_uuid"`
+	CreatedAt         types.String   `tfsdk:"created_at"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
+
+	// Write-only variants of the secrets, which are never stored in the state
+	AccessTokenWO       types.String `tfsdk:"access_token_wo"`
+	ServiceAccountKeyWO types.String `tfsdk:"service_account_key_wo"`
+	DockerConfigJSONWO  types.String `tfsdk:"docker_config_json_wo"`
+	SecretAccessKeyWO   types.String `tfsdk:"secret_access_key_wo"`
+	CredentialsVersion  types.Int64  `tfsdk:"credentials_version"`
+}
+
+// writeOnlyCredentialAttributes maps each write-only attribute to the attribute it replaces
+var writeOnlyCredentialAttributes = map[string]string{
+	"access_token_wo":        "access_token",
+	"service_account_key_wo": "service_account_key",
+	"docker_config_json_wo":  "docker_config_json",
+	"secret_access_key_wo":   "secret_access_key",
 }
 
 func (r *ContainerRegistryCredentialsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -51,15 +76,27 @@ func (r *ContainerRegistryCredentialsResource) Schema(ctx context.Context, req r
 
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
-				MarkdownDescription: "Name of the registry credentials",
-				Required:            true,
+				MarkdownDescription: "Name of the registry credentials. Conflicts with `name_prefix`; one of them is required.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name_prefix": schema.StringAttribute{
+				MarkdownDescription: "Creates a unique name beginning with this prefix. Use it with `create_before_destroy` to rotate the credentials without a moment in which deployments reference credentials that do not exist. Conflicts with `name`.",
+				Optional:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"type": schema.StringAttribute{
-				MarkdownDescription: "Type of registry: 'dockerhub', 'gcr', 'ghcr', 'ecr', 'scaleway', etc.",
+				MarkdownDescription: "Type of registry: 'dockerhub', 'ghcr', 'gcr', 'ecr', 'scaleway' or 'custom'",
 				Required:            true,
+				Validators: []validator.String{
+					stringOneOfValidator{values: []string{"dockerhub", "ghcr", "gcr", "ecr", "scaleway", "custom"}},
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -84,6 +121,9 @@ func (r *ContainerRegistryCredentialsResource) Schema(ctx context.Context, req r
 				MarkdownDescription: "Service account key JSON for GCR authentication",
 				Optional:            true,
 				Sensitive:           true,
+				Validators: []validator.String{
+					jsonStringValidator{},
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -92,6 +132,9 @@ func (r *ContainerRegistryCredentialsResource) Schema(ctx context.Context, req r
 				MarkdownDescription: "Docker config.json content for authentication",
 				Optional:            true,
 				Sensitive:           true,
+				Validators: []validator.String{
+					jsonStringValidator{},
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -144,10 +187,101 @@ func (r *ContainerRegistryCredentialsResource) Schema(ctx context.Context, req r
 				MarkdownDescription: "Timestamp when the credentials were created",
 				Computed:            true,
 			},
+			"access_token_wo": schema.StringAttribute{
+				MarkdownDescription: "Write-only variant of `access_token`, which is never stored in the state. Requires Terraform 1.11 or later. Change `credentials_version` to apply a new value.",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+			},
+			"service_account_key_wo": schema.StringAttribute{
+				MarkdownDescription: "Write-only variant of `service_account_key`, which is never stored in the state. Requires Terraform 1.11 or later. Change `credentials_version` to apply a new value.",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					jsonStringValidator{},
+				},
+				WriteOnly: true,
+			},
+			"docker_config_json_wo": schema.StringAttribute{
+				MarkdownDescription: "Write-only variant of `docker_config_json`, which is never stored in the state. Requires Terraform 1.11 or later. Change `credentials_version` to apply a new value.",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					jsonStringValidator{},
+				},
+				WriteOnly: true,
+			},
+			"secret_access_key_wo": schema.StringAttribute{
+				MarkdownDescription: "Write-only variant of `secret_access_key`, which is never stored in the state. Requires Terraform 1.11 or later. Change `credentials_version` to apply a new value.",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+			},
+			"credentials_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of the write-only credentials. Terraform cannot detect changes to write-only values, so change this to recreate the credentials with the current values.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
 		},
 	}
 }
 
+func (r *ContainerRegistryCredentialsResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		registryCredentialsValidator{},
+	}
+}
+
+func (r *ContainerRegistryCredentialsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var name, namePrefix types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("name"), &name)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("name_prefix"), &namePrefix)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !name.IsNull() && !namePrefix.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name_prefix"),
+			"Conflicting Attributes",
+			"Only one of name and name_prefix can be set.",
+		)
+	}
+
+	if name.IsNull() && namePrefix.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Missing Attribute",
+			"One of name or name_prefix must be set.",
+		)
+	}
+
+	// A secret can be set either as a normal or as a write-only attribute, but not both
+	for _, writeOnly := range slices.Sorted(maps.Keys(writeOnlyCredentialAttributes)) {
+		stored := writeOnlyCredentialAttributes[writeOnly]
+		var writeOnlyValue, storedValue types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(writeOnly), &writeOnlyValue)...)
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(stored), &storedValue)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if !writeOnlyValue.IsNull() && !storedValue.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(writeOnly),
+				"Conflicting Attributes",
+				fmt.Sprintf("Only one of %s and %s can be set. Use %s to keep the value out of the state.", stored, writeOnly, writeOnly),
+			)
+		}
+	}
+}
+
 func (r *ContainerRegistryCredentialsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -176,6 +310,20 @@ func (r *ContainerRegistryCredentialsResource) Create(ctx context.Context, req r
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	// The name is unknown when it is generated from name_prefix
+	if data.Name.IsUnknown() || data.Name.IsNull() {
+		data.Name = types.StringValue(generateRegistryCredentialsName(data.NamePrefix.ValueString()))
+	}
+
 	createReq := &verda.CreateRegistryCredentialsRequest{
 		Name: data.Name.ValueString(),
 		Type: data.Type.ValueString(),
@@ -221,9 +369,41 @@ func (r *ContainerRegistryCredentialsResource) Create(ctx context.Context, req r
 		createReq.ScalewayUUID = data.ScalewayUUID.ValueString()
 	}
 
+	// Write-only values are only available in the configuration, never in the plan
+	var config ContainerRegistryCredentialsResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.AccessTokenWO.IsNull() {
+		createReq.AccessToken = config.AccessTokenWO.ValueString()
+	}
+
+	if !config.ServiceAccountKeyWO.IsNull() {
+		createReq.ServiceAccountKey = config.ServiceAccountKeyWO.ValueString()
+	}
+
+	if !config.DockerConfigJSONWO.IsNull() {
+		createReq.DockerConfigJson = config.DockerConfigJSONWO.ValueString()
+	}
+
+	if !config.SecretAccessKeyWO.IsNull() {
+		createReq.SecretAccessKey = config.SecretAccessKeyWO.ValueString()
+	}
+
 	err := r.client.ContainerDeployments.CreateRegistryCredentials(ctx, createReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create registry credentials, got error: %s", err))
+		detail := fmt.Sprintf("Unable to create registry credentials, got error: %s", err)
+		// The API does not return stored secrets, so credentials left behind by the failed request cannot be verified and adopted
+		if isAmbiguousCreateError(err) {
+			lookupCtx, lookupCancel := ambiguousCreateLookupContext(ctx)
+			defer lookupCancel()
+			if r.credentialsExist(lookupCtx, data.Name.ValueString()) {
+				detail += unverifiedCreateHint("registry credentials", data.Name.ValueString())
+			}
+		}
+		resp.Diagnostics.AddError("Client Error", detail)
 		return
 	}
 
@@ -260,6 +440,15 @@ func (r *ContainerRegistryCredentialsResource) Read(ctx context.Context, req res
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	credentials, err := r.client.ContainerDeployments.GetRegistryCredentials(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read registry credentials, got error: %s", err))
@@ -287,18 +476,35 @@ func (r *ContainerRegistryCredentialsResource) Read(ctx context.Context, req res
 
 func (r *ContainerRegistryCredentialsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data ContainerRegistryCredentialsResourceModel
+	var state ContainerRegistryCredentialsResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	timeoutsOnly, err := planChangesOnly(req.Plan, req.State, "timeouts")
+	if err != nil {
+		resp.Diagnostics.AddError("Internal Error", fmt.Sprintf("Unable to compare plan with state, got error: %s", err))
+		return
+	}
+
 	// Registry credentials cannot be updated, only deleted and recreated
-	resp.Diagnostics.AddError(
-		"Update Not Supported",
-		"Registry credentials cannot be updated. Please delete and recreate the resource with new values.",
-	)
+	if !timeoutsOnly {
+		resp.Diagnostics.AddError(
+			"Update Not Supported",
+			"Registry credentials cannot be updated. Please delete and recreate the resource with new values, "+
+				"or use name_prefix with create_before_destroy to replace them without downtime.",
+		)
+		return
+	}
+
+	// Only the timeouts changed, which are not sent to the API
+	state.Timeouts = data.Timeouts
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 func (r *ContainerRegistryCredentialsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -310,8 +516,18 @@ func (r *ContainerRegistryCredentialsResource) Delete(ctx context.Context, req r
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	err := r.client.ContainerDeployments.DeleteRegistryCredentials(ctx, data.Name.ValueString(), false)
-	if err != nil {
+	// Already deleted outside of Terraform
+	if err != nil && !isNotFoundError(err) {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete registry credentials, got error: %s", err))
 		return
 	}
@@ -320,3 +536,20 @@ func (r *ContainerRegistryCredentialsResource) Delete(ctx context.Context, req r
 func (r *ContainerRegistryCredentialsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
 }
+
+// credentialsExist reports whether registry credentials with the given name exist. Failing to
+// list them counts as not existing.
+func (r *ContainerRegistryCredentialsResource) credentialsExist(ctx context.Context, name string) bool {
+	credentials, err := r.client.ContainerDeployments.GetRegistryCredentials(ctx)
+	if err != nil {
+		return false
+	}
+
+	return slices.ContainsFunc(credentials, func(cred verda.RegistryCredentials) bool { return cred.Name == name })
+}
+
+// generateRegistryCredentialsName returns a unique name beginning with prefix, made of the
+// creation time and a random suffix, so names generated in the same second do not collide
+func generateRegistryCredentialsName(prefix string) string {
+	return fmt.Sprintf("%s%s%04x", prefix, time.Now().UTC().Format("20060102150405"), rand.N(1<<16))
+}
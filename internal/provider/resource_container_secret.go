@@ -0,0 +1,273 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda"
+)
+
+var _ resource.Resource = &ContainerSecretResource{}
+var _ resource.ResourceWithImportState = &ContainerSecretResource{}
+
+func NewContainerSecretResource() resource.Resource {
+	return &ContainerSecretResource{}
+}
+
+type ContainerSecretResource struct {
+	client *verda.Client
+}
+
+type ContainerSecretResourceModel struct {
+	Name       types.String   `tfsdk:"name"`
+	Value      types.String   `tfsdk:"value"`
+	SecretType types.String   `tfsdk:"secret_type"`
+	CreatedAt  types.String   `tfsdk:"created_at"`
+	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *ContainerSecretResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_container_secret"
+}
+
+func (r *ContainerSecretResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a secret that container deployments and serverless jobs can reference in environment variables",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the secret",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "Value of the secret",
+				Required:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						requiresReplaceUnlessImported,
+						"Changing the value recreates the secret, unless the secret was imported and its value is not known yet.",
+						"Changing the value recreates the secret, unless the secret was imported and its value is not known yet.",
+					),
+				},
+			},
+			"secret_type": schema.StringAttribute{
+				MarkdownDescription: "Type of the secret as reported by the API",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "Timestamp when the secret was created",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
+	}
+}
+
+func (r *ContainerSecretResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*verda.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *verda.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ContainerSecretResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ContainerSecretResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	err := r.client.ContainerDeployments.CreateSecret(ctx, &verda.CreateSecretRequest{
+		Name:  data.Name.ValueString(),
+		Value: data.Value.ValueString(),
+	})
+	if err != nil {
+		detail := fmt.Sprintf("Unable to create secret, got error: %s", err)
+		// The API does not return secret values, so a secret left behind by the failed request cannot be verified and adopted
+		if isAmbiguousCreateError(err) {
+			lookupCtx, lookupCancel := ambiguousCreateLookupContext(ctx)
+			defer lookupCancel()
+			if existing, findErr := r.findSecret(lookupCtx, data.Name.ValueString()); findErr == nil && existing != nil {
+				detail += unverifiedCreateHint("a secret", data.Name.ValueString())
+			}
+		}
+		resp.Diagnostics.AddError("Client Error", detail)
+		return
+	}
+
+	// The API doesn't return the created secret, so we need to fetch it
+	secret, err := r.findSecret(ctx, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read secret after creation, got error: %s", err))
+		return
+	}
+
+	if secret == nil {
+		resp.Diagnostics.AddWarning("Secret Not Found", "Created secret could not be found in the list")
+		data.SecretType = types.StringNull()
+		data.CreatedAt = types.StringNull()
+	} else {
+		flattenSecretToModel(secret, &data)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ContainerSecretResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ContainerSecretResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	secret, err := r.findSecret(ctx, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read secret, got error: %s", err))
+		return
+	}
+
+	if secret == nil {
+		// The secret was deleted outside of Terraform, so plan to recreate it
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// The API never returns the value, so the value from the state is kept
+	flattenSecretToModel(secret, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ContainerSecretResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ContainerSecretResourceModel
+	var state ContainerSecretResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// name and value require replacement, so only the timeouts can change here, or the value
+	// of an imported secret, which the API does not return, is filled in from the configuration
+	state.Value = data.Value
+	state.Timeouts = data.Timeouts
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *ContainerSecretResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ContainerSecretResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.client.ContainerDeployments.DeleteSecret(ctx, data.Name.ValueString(), false)
+	// Already deleted outside of Terraform
+	if err != nil && !isNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to delete secret, got error: %s. Secrets that are still referenced by a deployment cannot be deleted.", err),
+		)
+		return
+	}
+}
+
+func (r *ContainerSecretResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
+
+// findSecret returns the secret with the given name, or nil if it does not exist
+func (r *ContainerSecretResource) findSecret(ctx context.Context, name string) (*verda.Secret, error) {
+	secrets, err := r.client.ContainerDeployments.GetSecrets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range secrets {
+		if secrets[i].Name == name {
+			return &secrets[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// requiresReplaceUnlessImported requires replacement when a secret value changes. Imported
+// secrets have no value in the state, since the API never returns it, so setting it is not a change.
+func requiresReplaceUnlessImported(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = !req.StateValue.IsNull()
+}
+
+func flattenSecretToModel(secret *verda.Secret, data *ContainerSecretResourceModel) {
+	data.SecretType = types.StringValue(secret.SecretType)
+	data.CreatedAt = types.StringValue(secret.CreatedAt.Format(time.RFC3339))
+}
@@ -0,0 +1,262 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda"
+)
+
+// testContainerState returns the state of a container deployment named app with the given scaling
+func testContainerState(t *testing.T, maxReplicaCount int64, deadlineSeconds types.Int64) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	NewContainerResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("Schema() diagnostics = %v", schemaResp.Diagnostics)
+	}
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+
+	var diags diag.Diagnostics
+	diags.Append(state.SetAttribute(ctx, path.Root("name"), "app")...)
+	diags.Append(state.SetAttribute(ctx, path.Root("is_spot"), false)...)
+	diags.Append(state.SetAttribute(ctx, path.Root("endpoint_base_url"), "https://app.example.com")...)
+	diags.Append(state.SetAttribute(ctx, path.Root("created_at"), "2025-01-01T00:00:00Z")...)
+	diags.Append(state.SetAttribute(ctx, path.Root("compute").AtName("name"), "H100")...)
+	diags.Append(state.SetAttribute(ctx, path.Root("compute").AtName("size"), 1)...)
+	diags.Append(state.SetAttribute(ctx, path.Root("containers").AtListIndex(0).AtName("image"), "registry.example.com/app:1.0")...)
+	diags.Append(state.SetAttribute(ctx, path.Root("containers").AtListIndex(0).AtName("exposed_port"), 8080)...)
+
+	scaling := path.Root("scaling")
+	diags.Append(state.SetAttribute(ctx, scaling.AtName("min_replica_count"), 0)...)
+	diags.Append(state.SetAttribute(ctx, scaling.AtName("max_replica_count"), maxReplicaCount)...)
+	diags.Append(state.SetAttribute(ctx, scaling.AtName("queue_message_ttl_seconds"), 300)...)
+	diags.Append(state.SetAttribute(ctx, scaling.AtName("deadline_seconds"), deadlineSeconds)...)
+	diags.Append(state.SetAttribute(ctx, scaling.AtName("concurrent_requests_per_replica"), 1)...)
+	diags.Append(state.SetAttribute(ctx, scaling.AtName("scale_down_policy").AtName("delay_seconds"), 300)...)
+	diags.Append(state.SetAttribute(ctx, scaling.AtName("scale_up_policy").AtName("delay_seconds"), 0)...)
+	diags.Append(state.SetAttribute(ctx, scaling.AtName("queue_load").AtName("threshold"), 1)...)
+	if diags.HasError() {
+		t.Fatalf("SetAttribute() diagnostics = %v", diags)
+	}
+	return state
+}
+
+// testContainerDeployment returns the container deployment described by testContainerState
+func testContainerDeployment() verda.ContainerDeployment {
+	return verda.ContainerDeployment{
+		Name:            "app",
+		EndpointBaseURL: "https://app.example.com",
+		Compute:         &verda.ContainerCompute{Name: "H100", Size: 1},
+		Containers: []verda.DeploymentContainer{{
+			Image:       verda.ContainerImage{Image: "registry.example.com/app:1.0"},
+			ExposedPort: 8080,
+		}},
+	}
+}
+
+// testContainerScaling returns the scaling options described by testContainerState
+func testContainerScaling(maxReplicaCount int) verda.ContainerScalingOptions {
+	return verda.ContainerScalingOptions{
+		MinReplicaCount:              0,
+		MaxReplicaCount:              maxReplicaCount,
+		ScaleDownPolicy:              &verda.ScalingPolicy{DelaySeconds: 300},
+		ScaleUpPolicy:                &verda.ScalingPolicy{DelaySeconds: 0},
+		QueueMessageTTLSeconds:       300,
+		ConcurrentRequestsPerReplica: 1,
+		ScalingTriggers:              &verda.ScalingTriggers{QueueLoad: &verda.QueueLoadTrigger{Threshold: 1}},
+	}
+}
+
+func TestContainerUpdateScaling(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		priorDeadline types.Int64
+		deadline      types.Int64
+	}{
+		{name: "deadline differs from the queue TTL", priorDeadline: types.Int64Value(600), deadline: types.Int64Value(600)},
+		{name: "deadline changed", priorDeadline: types.Int64Value(600), deadline: types.Int64Value(900)},
+		{name: "deadline not set", priorDeadline: types.Int64Null(), deadline: types.Int64Null()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scaling := testContainerScaling(1)
+
+			var scalingUpdated bool
+			client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+
+				switch {
+				case r.Method == http.MethodPatch && r.URL.Path == "/container-deployments/app/scaling":
+					var updateReq verda.UpdateScalingOptionsRequest
+					if err := json.NewDecoder(r.Body).Decode(&updateReq); err != nil {
+						t.Errorf("decoding scaling update: %v", err)
+					}
+					if updateReq.MaxReplicaCount != nil {
+						scaling.MaxReplicaCount = *updateReq.MaxReplicaCount
+					}
+					scalingUpdated = true
+					_ = json.NewEncoder(w).Encode(scaling)
+				case r.Method == http.MethodGet && r.URL.Path == "/container-deployments/app/scaling":
+					_ = json.NewEncoder(w).Encode(scaling)
+				case r.Method == http.MethodGet && r.URL.Path == "/container-deployments/app":
+					_ = json.NewEncoder(w).Encode(testContainerDeployment())
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+
+			priorState := testContainerState(t, 1, tt.priorDeadline)
+			plan := testContainerState(t, 3, tt.deadline)
+
+			req := resource.UpdateRequest{
+				Plan:   tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+				Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+				State:  priorState,
+			}
+			resp := resource.UpdateResponse{State: tfsdk.State{Schema: priorState.Schema, Raw: priorState.Raw}}
+
+			r := &ContainerResource{client: client}
+			r.Update(ctx, req, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Update() diagnostics = %v", resp.Diagnostics)
+			}
+			if !scalingUpdated {
+				t.Errorf("scaling was not updated")
+			}
+
+			var gotMax, gotTTL, gotDeadline types.Int64
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("scaling").AtName("max_replica_count"), &gotMax)...)
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("scaling").AtName("queue_message_ttl_seconds"), &gotTTL)...)
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("scaling").AtName("deadline_seconds"), &gotDeadline)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("GetAttribute() diagnostics = %v", resp.Diagnostics)
+			}
+
+			if gotMax.ValueInt64() != 3 {
+				t.Errorf("max_replica_count = %s, want 3", gotMax)
+			}
+			if gotTTL.ValueInt64() != 300 {
+				t.Errorf("queue_message_ttl_seconds = %s, want 300", gotTTL)
+			}
+			if !gotDeadline.Equal(tt.deadline) {
+				t.Errorf("deadline_seconds = %s, want %s", gotDeadline, tt.deadline)
+			}
+		})
+	}
+}
+
+func TestContainerCreateAdoptsAfterTimeout(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		exists      bool
+		maxReplicas int
+		wantError   string
+		wantWarning string
+	}{
+		{name: "deployment matches the plan", exists: true, maxReplicas: 3, wantWarning: "Adopted Existing Container Deployment"},
+		{name: "deployment differs from the plan", exists: true, maxReplicas: 1, wantError: "Container Deployment Already Exists"},
+		{name: "deployment was not created", exists: false, wantError: "Client Error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			release := make(chan struct{})
+			client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+
+				switch {
+				case r.Method == http.MethodPost && r.URL.Path == "/container-deployments":
+					// Answer only after the client gave up, as a create that outlives the create timeout
+					select {
+					case <-r.Context().Done():
+					case <-release:
+					}
+				case !tt.exists:
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"code":"not_found","message":"deployment not found"}`))
+				case r.Method == http.MethodGet && r.URL.Path == "/container-deployments/app":
+					_ = json.NewEncoder(w).Encode(testContainerDeployment())
+				case r.Method == http.MethodGet && r.URL.Path == "/container-deployments/app/scaling":
+					_ = json.NewEncoder(w).Encode(testContainerScaling(tt.maxReplicas))
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+
+			// Release the create request before the server is closed
+			t.Cleanup(func() { close(release) })
+
+			plan := testContainerState(t, 3, types.Int64Value(600))
+			diags := plan.SetAttribute(ctx, path.Root("timeouts").AtName("create"), "100ms")
+			if diags.HasError() {
+				t.Fatalf("SetAttribute() diagnostics = %v", diags)
+			}
+
+			req := resource.CreateRequest{
+				Plan:   tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+				Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+			}
+			resp := resource.CreateResponse{State: tfsdk.State{
+				Schema: plan.Schema,
+				Raw:    tftypes.NewValue(plan.Schema.Type().TerraformType(ctx), nil),
+			}}
+
+			r := &ContainerResource{client: client}
+			r.Create(ctx, req, &resp)
+
+			var gotErrors, gotWarnings []string
+			for _, d := range resp.Diagnostics.Errors() {
+				gotErrors = append(gotErrors, d.Summary())
+			}
+			for _, d := range resp.Diagnostics.Warnings() {
+				gotWarnings = append(gotWarnings, d.Summary())
+			}
+			if tt.wantError == "" && len(gotErrors) > 0 || tt.wantError != "" && !slices.Equal(gotErrors, []string{tt.wantError}) {
+				t.Fatalf("errors = %v, want %q", resp.Diagnostics.Errors(), tt.wantError)
+			}
+			if tt.wantWarning != "" && !slices.Equal(gotWarnings, []string{tt.wantWarning}) {
+				t.Errorf("warnings = %v, want %q", gotWarnings, tt.wantWarning)
+			}
+			if tt.wantError != "" {
+				return
+			}
+
+			var gotName types.String
+			var gotDeadline types.Int64
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("name"), &gotName)...)
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("scaling").AtName("deadline_seconds"), &gotDeadline)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("GetAttribute() diagnostics = %v", resp.Diagnostics)
+			}
+			if gotName.ValueString() != "app" {
+				t.Errorf("name = %s, want app", gotName)
+			}
+			if gotDeadline.ValueInt64() != 600 {
+				t.Errorf("deadline_seconds = %s, want 600", gotDeadline)
+			}
+		})
+	}
+}
@@ -2,8 +2,12 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -13,6 +17,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda"
@@ -20,6 +25,8 @@ import (
 
 var _ resource.Resource = &InstanceResource{}
 var _ resource.ResourceWithImportState = &InstanceResource{}
+var _ resource.ResourceWithModifyPlan = &InstanceResource{}
+var _ resource.ResourceWithValidateConfig = &InstanceResource{}
 
 func NewInstanceResource() resource.Resource {
 	return &InstanceResource{}
@@ -30,31 +37,37 @@ type InstanceResource struct {
 }
 
 type InstanceResourceModel struct {
-	ID              types.String  `tfsdk:"id"`
-	InstanceType    types.String  `tfsdk:"instance_type"`
-	Image           types.String  `tfsdk:"image"`
-	Hostname        types.String  `tfsdk:"hostname"`
-	Description     types.String  `tfsdk:"description"`
-	PricePerHour    types.Float64 `tfsdk:"price_per_hour"`
-	IP              types.String  `tfsdk:"ip"`
-	Status          types.String  `tfsdk:"status"`
-	CreatedAt       types.String  `tfsdk:"created_at"`
-	SSHKeyIDs       types.List    `tfsdk:"ssh_key_ids"`
-	Location        types.String  `tfsdk:"location"`
-	IsSpot          types.Bool    `tfsdk:"is_spot"`
-	OSName          types.String  `tfsdk:"os_name"`
-	StartupScriptID types.String  `tfsdk:"startup_script_id"`
-	OSVolumeID      types.String  `tfsdk:"os_volume_id"`
-	Contract        types.String  `tfsdk:"contract"`
-	Pricing         types.String  `tfsdk:"pricing"`
-	CPU             types.Object  `tfsdk:"cpu"`
-	GPU             types.Object  `tfsdk:"gpu"`
-	Memory          types.Object  `tfsdk:"memory"`
-	GPUMemory       types.Object  `tfsdk:"gpu_memory"`
-	Storage         types.Object  `tfsdk:"storage"`
-	Volumes         types.List    `tfsdk:"volumes"`
-	ExistingVolumes types.List    `tfsdk:"existing_volumes"`
-	OSVolume        types.Object  `tfsdk:"os_volume"`
+	ID                 types.String   `tfsdk:"id"`
+	InstanceType       types.String   `tfsdk:"instance_type"`
+	Image              types.String   `tfsdk:"image"`
+	Hostname           types.String   `tfsdk:"hostname"`
+	Description        types.String   `tfsdk:"description"`
+	PricePerHour       types.Float64  `tfsdk:"price_per_hour"`
+	IP                 types.String   `tfsdk:"ip"`
+	Status             types.String   `tfsdk:"status"`
+	CreatedAt          types.String   `tfsdk:"created_at"`
+	SSHKeyIDs          types.List     `tfsdk:"ssh_key_ids"`
+	Location           types.String   `tfsdk:"location"`
+	IsSpot             types.Bool     `tfsdk:"is_spot"`
+	OSName             types.String   `tfsdk:"os_name"`
+	StartupScriptID    types.String   `tfsdk:"startup_script_id"`
+	OSVolumeID         types.String   `tfsdk:"os_volume_id"`
+	Contract           types.String   `tfsdk:"contract"`
+	Pricing            types.String   `tfsdk:"pricing"`
+	CPU                types.Object   `tfsdk:"cpu"`
+	GPU                types.Object   `tfsdk:"gpu"`
+	Memory             types.Object   `tfsdk:"memory"`
+	GPUMemory          types.Object   `tfsdk:"gpu_memory"`
+	Storage            types.Object   `tfsdk:"storage"`
+	Volumes            types.List     `tfsdk:"volumes"`
+	ExistingVolumes    types.List     `tfsdk:"existing_volumes"`
+	OSVolume           types.Object   `tfsdk:"os_volume"`
+	CapacityCheck      types.String   `tfsdk:"capacity_check"`
+	LocationPrefs      types.List     `tfsdk:"location_preferences"`
+	WaitForCapacity    types.String   `tfsdk:"wait_for_capacity"`
+	DesiredStatus      types.String   `tfsdk:"desired_status"`
+	DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
 }
 
 type CPUModel struct {
@@ -330,6 +343,41 @@ func (r *InstanceResource) Schema(ctx context.Context, req resource.SchemaReques
 					objectplanmodifier.RequiresReplace(),
 				},
 			},
+			"capacity_check": schema.StringAttribute{
+				MarkdownDescription: "Check at plan time whether the instance type is currently available in the location. " +
+					"Set to 'warn' to report a warning or 'error' to fail the plan when it is not. Disabled when unset.",
+				Optional: true,
+				Validators: []validator.String{
+					stringOneOfValidator{values: []string{capacityCheckWarn, capacityCheckError}},
+				},
+			},
+			"location_preferences": schema.ListAttribute{
+				MarkdownDescription: "Locations to try in order when creating the instance. The first location with capacity for the instance type is used " +
+					"and written to `location`. Conflicts with `location`.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"desired_status": schema.StringAttribute{
+				MarkdownDescription: "Power state to keep the instance in: 'running' or 'shutdown'. " +
+					"Changing it starts or shuts down the instance in place, keeping its volumes. The power state is not managed when unset.",
+				Optional: true,
+				Validators: []validator.String{
+					stringOneOfValidator{values: []string{desiredStatusRunning, desiredStatusShutdown}},
+				},
+			},
+			"wait_for_capacity": schema.StringAttribute{
+				MarkdownDescription: "How long to keep retrying the locations when creating the instance fails in all of them for lack of capacity (e.g., '30m'). " +
+					"When unset, each location is tried once.",
+				Optional: true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"deletion_protection": deletionProtectionAttribute("instance"),
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
 		},
 	}
 }
@@ -362,12 +410,20 @@ func (r *InstanceResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultInstanceCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	createReq := verda.CreateInstanceRequest{
 		InstanceType: data.InstanceType.ValueString(),
 		Image:        data.Image.ValueString(),
 		Hostname:     data.Hostname.ValueString(),
 		Description:  data.Description.ValueString(),
-		LocationCode: data.Location.ValueString(),
 		IsSpot:       data.IsSpot.ValueBool(),
 	}
 
@@ -434,25 +490,28 @@ func (r *InstanceResource) Create(ctx context.Context, req resource.CreateReques
 		}
 	}
 
-	instance, err := r.client.Instances.Create(ctx, createReq)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create instance, got error: %s", err))
+	instance := r.createInstance(ctx, createReq, &data, resp)
+	if instance == nil || resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Save the instance ID to state immediately to prevent duplicate creation
-	// even if subsequent operations fail
-	data.ID = types.StringValue(instance.ID)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-	if resp.Diagnostics.HasError() {
+	// New instances always boot, so other power states are applied once they are running
+	if data.DesiredStatus.IsNull() || data.DesiredStatus.ValueString() == desiredStatusRunning {
 		return
 	}
 
-	// Now populate the rest of the instance data
-	r.flattenInstanceToModel(ctx, instance, &data, &resp.Diagnostics)
+	instance, err := r.setPowerState(ctx, instance.ID, data.DesiredStatus.ValueString())
+	if instance != nil {
+		r.flattenInstanceToModel(ctx, instance, &data, &resp.Diagnostics)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	}
 
-	// Update state with full instance details (even if there were non-critical errors)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Instance %s was created but could not be set to %s, got error: %s", data.ID.ValueString(), data.DesiredStatus.ValueString(), err),
+		)
+	}
 }
 
 func (r *InstanceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -464,14 +523,40 @@ func (r *InstanceResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	instance, err := r.client.Instances.GetByID(ctx, data.ID.ValueString())
 	if err != nil {
+		if isNotFoundError(err) {
+			// The instance was deleted outside of Terraform, so plan to recreate it
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read instance, got error: %s", err))
 		return
 	}
 
 	r.flattenInstanceToModel(ctx, instance, &data, &resp.Diagnostics)
 
+	// Report power state changes made outside of Terraform so the next apply restores desired_status
+	if !data.DesiredStatus.IsNull() {
+		switch instance.Status {
+		case verda.StatusRunning:
+			data.DesiredStatus = types.StringValue(desiredStatusRunning)
+		case verda.StatusOffline:
+			if data.DesiredStatus.ValueString() == desiredStatusRunning {
+				data.DesiredStatus = types.StringValue(desiredStatusShutdown)
+			}
+		}
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -481,18 +566,62 @@ func (r *InstanceResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 func (r *InstanceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data InstanceResourceModel
+	var state InstanceResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	settingsOnly, err := planChangesOnly(req.Plan, req.State, "capacity_check", "location_preferences", "wait_for_capacity", "desired_status", "deletion_protection", "timeouts")
+	if err != nil {
+		resp.Diagnostics.AddError("Internal Error", fmt.Sprintf("Unable to compare plan with state, got error: %s", err))
+		return
+	}
+
 	// Instances cannot be updated in the Verda API, only deleted and recreated
-	resp.Diagnostics.AddError(
-		"Update Not Supported",
-		"Instances cannot be updated for now. Most changes require replacing the resource.",
-	)
+	if !settingsOnly {
+		resp.Diagnostics.AddError(
+			"Update Not Supported",
+			"Instances cannot be updated for now. Most changes require replacing the resource.",
+		)
+		return
+	}
+
+	state.CapacityCheck = data.CapacityCheck
+	state.LocationPrefs = data.LocationPrefs
+	state.WaitForCapacity = data.WaitForCapacity
+	state.DeletionProtection = data.DeletionProtection
+	state.Timeouts = data.Timeouts
+
+	if data.DesiredStatus.IsNull() || data.DesiredStatus.Equal(state.DesiredStatus) {
+		// Only provider-side settings changed, keep the instance as it is
+		state.DesiredStatus = data.DesiredStatus
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultInstanceUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	instance, err := r.setPowerState(ctx, state.ID.ValueString(), data.DesiredStatus.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set instance to %s, got error: %s", data.DesiredStatus.ValueString(), err))
+		return
+	}
+
+	r.flattenInstanceToModel(ctx, instance, &state, &resp.Diagnostics)
+	state.DesiredStatus = data.DesiredStatus
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 func (r *InstanceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -504,13 +633,333 @@ func (r *InstanceResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
+	if checkDeletionProtection(data.DeletionProtection, "instance", data.Hostname.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultInstanceDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	err := r.client.Instances.Delete(ctx, []string{}, data.ID.ValueString())
-	if err != nil {
+	// Already deleted outside of Terraform
+	if err != nil && !isNotFoundError(err) {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete instance, got error: %s", err))
 		return
 	}
 }
 
+func (r *InstanceResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data InstanceResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Location.IsNull() && !data.LocationPrefs.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("location_preferences"),
+			"Conflicting Attributes",
+			"location_preferences cannot be used together with location. The selected location is written to location.",
+		)
+	}
+
+	if !data.LocationPrefs.IsNull() && !data.LocationPrefs.IsUnknown() && len(data.LocationPrefs.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("location_preferences"),
+			"Invalid Attribute Value",
+			"location_preferences must contain at least one location",
+		)
+	}
+}
+
+// ModifyPlan checks the capacity of the requested instance type when capacity_check is set
+func (r *InstanceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the instance is being destroyed or already exists
+	if req.Plan.Raw.IsNull() || !req.State.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var data InstanceResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.CapacityCheck.IsNull() || data.CapacityCheck.IsUnknown() {
+		return
+	}
+
+	// Skip the check when values are not known until apply
+	if data.InstanceType.IsUnknown() || data.IsSpot.IsUnknown() {
+		return
+	}
+
+	var configLocation types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("location"), &configLocation)...)
+	if resp.Diagnostics.HasError() || configLocation.IsUnknown() || data.LocationPrefs.IsUnknown() {
+		return
+	}
+	data.Location = configLocation
+
+	locations := r.candidateLocations(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, location := range locations {
+		available, err := r.client.InstanceAvailability.GetInstanceTypeAvailability(ctx, data.InstanceType.ValueString(), data.IsSpot.ValueBool(), location)
+		if err != nil {
+			resp.Diagnostics.AddWarning(
+				"Unable to Check Capacity",
+				fmt.Sprintf("Unable to check availability of instance type %s in %s, got error: %s", data.InstanceType.ValueString(), location, err),
+			)
+			return
+		}
+
+		if available {
+			return
+		}
+	}
+
+	summary := "Instance Type Unavailable"
+	detail := fmt.Sprintf("Instance type %s is currently not available in %s", data.InstanceType.ValueString(), strings.Join(locations, ", "))
+	if data.IsSpot.ValueBool() {
+		detail += " as a spot instance"
+	}
+	detail += ". Use the verda_instance_availability data source to find a location with capacity"
+	if !data.WaitForCapacity.IsNull() {
+		detail += ", or rely on wait_for_capacity to retry during apply"
+	}
+	detail += "."
+
+	if data.CapacityCheck.ValueString() == capacityCheckError {
+		resp.Diagnostics.AddAttributeError(path.Root("instance_type"), summary, detail)
+		return
+	}
+
+	resp.Diagnostics.AddAttributeWarning(path.Root("instance_type"), summary, detail)
+}
+
+// candidateLocations returns the locations to consider for a new instance, in order of preference
+func (r *InstanceResource) candidateLocations(ctx context.Context, data *InstanceResourceModel, diagnostics *diag.Diagnostics) []string {
+	if !data.LocationPrefs.IsNull() {
+		var locations []string
+		diagnostics.Append(data.LocationPrefs.ElementsAs(ctx, &locations, false)...)
+		return locations
+	}
+
+	if data.Location.IsNull() || data.Location.IsUnknown() || data.Location.ValueString() == "" {
+		return []string{defaultInstanceLocation}
+	}
+
+	return []string{data.Location.ValueString()}
+}
+
+// createInstance creates the instance and waits for it to reach running status. Without
+// location_preferences and wait_for_capacity it is created once in the configured location.
+// Otherwise the candidate locations are tried in order, until wait_for_capacity expires: a
+// location is skipped when the availability check reports no capacity, when the create is
+// rejected for capacity, or when the instance fails to provision, in which case it is deleted
+// again. The instance is returned once it runs; on failure the diagnostics hold the error,
+// and an instance that could not be cleaned up is left in the state so that it is tainted.
+func (r *InstanceResource) createInstance(ctx context.Context, createReq verda.CreateInstanceRequest, data *InstanceResourceModel, resp *resource.CreateResponse) *verda.Instance {
+	if data.LocationPrefs.IsNull() && data.WaitForCapacity.IsNull() {
+		createReq.LocationCode = data.Location.ValueString()
+		instance, _ := r.createInLocation(ctx, createReq, data, resp, false)
+		return instance
+	}
+
+	locations := r.candidateLocations(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return nil
+	}
+
+	var waitFor time.Duration
+	if !data.WaitForCapacity.IsNull() {
+		var err error
+		waitFor, err = time.ParseDuration(data.WaitForCapacity.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Attribute Value", fmt.Sprintf("Unable to parse wait_for_capacity, got error: %s", err))
+			return nil
+		}
+	}
+
+	deadline := time.Now().Add(waitFor)
+	instanceType := data.InstanceType.ValueString()
+	isSpot := data.IsSpot.ValueBool()
+
+	var lastErr error
+	for {
+		for _, location := range locations {
+			available, err := r.client.InstanceAvailability.GetInstanceTypeAvailability(ctx, instanceType, isSpot, location)
+			// The check is only a shortcut, the create itself is tried when it fails
+			if err == nil && !available {
+				lastErr = fmt.Errorf("%w in %s", errInsufficientCapacity, location)
+				continue
+			}
+
+			createReq.LocationCode = location
+			data.Location = types.StringValue(location)
+			instance, err := r.createInLocation(ctx, createReq, data, resp, true)
+			if !errors.Is(err, errInsufficientCapacity) {
+				return instance
+			}
+			lastErr = err
+		}
+
+		if !time.Now().Before(deadline) {
+			break
+		}
+
+		// Wait 30 seconds before trying again, unless the create timeout expires first
+		select {
+		case <-ctx.Done():
+			resp.Diagnostics.AddError(
+				"Insufficient Capacity",
+				fmt.Sprintf("Timeout waiting for capacity for instance type %s in %s, last error: %s", instanceType, strings.Join(locations, ", "), lastErr),
+			)
+			return nil
+		case <-time.After(30 * time.Second):
+		}
+	}
+
+	resp.Diagnostics.AddError(
+		"Insufficient Capacity",
+		fmt.Sprintf("Unable to create instance type %s in %s, last error: %s", instanceType, strings.Join(locations, ", "), lastErr),
+	)
+	return nil
+}
+
+// createInLocation creates the instance in the location set in createReq and waits for it
+// to run. With fallback set, a capacity failure is returned as an error wrapping
+// errInsufficientCapacity, after deleting the failed instance, so the caller can try the
+// next location. All other failures are added to the diagnostics.
+func (r *InstanceResource) createInLocation(ctx context.Context, createReq verda.CreateInstanceRequest, data *InstanceResourceModel, resp *resource.CreateResponse, fallback bool) (*verda.Instance, error) {
+	instance, err := r.client.Instances.Create(ctx, createReq)
+	if err != nil {
+		if fallback && isCapacityError(err) {
+			return nil, fmt.Errorf("%w in %s: %s", errInsufficientCapacity, createReq.LocationCode, err)
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create instance, got error: %s", err))
+		return nil, nil
+	}
+
+	// Save the instance ID to state immediately to prevent duplicate creation
+	// even if subsequent operations fail
+	data.ID = types.StringValue(instance.ID)
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return nil, nil
+	}
+
+	// Wait for the instance to finish provisioning so that the IP and OS volume are known
+	running, err := r.waitForInstanceStatus(ctx, instance.ID, verda.StatusRunning)
+	if running != nil {
+		instance = running
+	}
+
+	// An instance that failed to provision in this location is removed, so the next one can be tried
+	if err != nil && fallback && (instance.Status == verda.StatusNoCapacity || instance.Status == verda.StatusError) {
+		deleteErr := r.client.Instances.Delete(ctx, []string{}, instance.ID)
+		if deleteErr == nil || isNotFoundError(deleteErr) {
+			resp.State.RemoveResource(ctx)
+			data.ID = types.StringUnknown()
+			return nil, fmt.Errorf("%w in %s: instance %s entered %s status", errInsufficientCapacity, createReq.LocationCode, instance.ID, instance.Status)
+		}
+
+		err = fmt.Errorf("%w, and deleting it failed: %s", err, deleteErr)
+	}
+
+	// Now populate the rest of the instance data
+	r.flattenInstanceToModel(ctx, instance, data, &resp.Diagnostics)
+
+	// Update state with full instance details (even if there were non-critical errors)
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+
+	// Returning an error after the state is saved taints the instance, so it is replaced on the next apply
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Instance Provisioning Failed",
+			fmt.Sprintf("Instance %s was created but did not reach %s status, got error: %s", instance.ID, verda.StatusRunning, err),
+		)
+	}
+
+	return instance, nil
+}
+
+// setPowerState runs the instance action for the desired status and waits for the transition
+// to finish. Nothing is done when the instance already has the target status.
+func (r *InstanceResource) setPowerState(ctx context.Context, instanceID string, desiredStatus string) (*verda.Instance, error) {
+	targetStatus := verda.StatusOffline
+	if desiredStatus == desiredStatusRunning {
+		targetStatus = verda.StatusRunning
+	}
+
+	instance, err := r.client.Instances.GetByID(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if instance.Status == targetStatus {
+		return instance, nil
+	}
+
+	switch desiredStatus {
+	case desiredStatusRunning:
+		err = r.client.Instances.Start(ctx, instanceID)
+	case desiredStatusShutdown:
+		err = r.client.Instances.Shutdown(ctx, instanceID)
+	default:
+		return nil, fmt.Errorf("unsupported desired status %q", desiredStatus)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return r.waitForInstanceStatus(ctx, instanceID, targetStatus)
+}
+
+// waitForInstanceStatus polls the instance until it reaches the target status, a terminal
+// error status, or the context deadline. The last instance read is returned even on error.
+func (r *InstanceResource) waitForInstanceStatus(ctx context.Context, instanceID string, targetStatus string) (*verda.Instance, error) {
+	var instance *verda.Instance
+
+	for {
+		current, err := r.client.Instances.GetByID(ctx, instanceID)
+		if err == nil {
+			instance = current
+
+			switch instance.Status {
+			case targetStatus:
+				return instance, nil
+			case verda.StatusError, verda.StatusNoCapacity, verda.StatusDiscontinued, verda.StatusNotFound:
+				return instance, fmt.Errorf("instance entered %s status", instance.Status)
+			}
+		}
+		// For other errors, continue polling (the instance might not be visible yet)
+
+		select {
+		case <-ctx.Done():
+			if instance != nil {
+				return instance, fmt.Errorf("timeout waiting for instance status %s, last status was %s: %w", targetStatus, instance.Status, ctx.Err())
+			}
+			return nil, fmt.Errorf("timeout waiting for instance status %s: %w", targetStatus, ctx.Err())
+		case <-time.After(10 * time.Second):
+		}
+	}
+}
+
 func (r *InstanceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
@@ -552,70 +1001,90 @@ func (r *InstanceResource) flattenInstanceToModel(ctx context.Context, instance
 	diagnostics.Append(diags...)
 	data.SSHKeyIDs = sshKeyList
 
-	cpuObj, cpuDiags := types.ObjectValue(
-		map[string]attr.Type{
-			"description":     types.StringType,
-			"number_of_cores": types.Int64Type,
-		},
-		map[string]attr.Value{
-			"description":     types.StringValue(instance.CPU.Description),
-			"number_of_cores": types.Int64Value(int64(instance.CPU.NumberOfCores)),
-		},
-	)
+	cpuObj, cpuDiags := flattenInstanceCPU(instance.CPU)
 	diagnostics.Append(cpuDiags...)
 	data.CPU = cpuObj
 
-	gpuObj, gpuDiags := types.ObjectValue(
-		map[string]attr.Type{
-			"description":    types.StringType,
-			"number_of_gpus": types.Int64Type,
-		},
-		map[string]attr.Value{
-			"description":    types.StringValue(instance.GPU.Description),
-			"number_of_gpus": types.Int64Value(int64(instance.GPU.NumberOfGPUs)),
-		},
-	)
+	gpuObj, gpuDiags := flattenInstanceGPU(instance.GPU)
 	diagnostics.Append(gpuDiags...)
 	data.GPU = gpuObj
 
-	gpuMemoryObj, gpuMemDiags := types.ObjectValue(
-		map[string]attr.Type{
-			"description":       types.StringType,
-			"size_in_gigabytes": types.Int64Type,
-		},
-		map[string]attr.Value{
-			"description":       types.StringValue(instance.GPUMemory.Description),
-			"size_in_gigabytes": types.Int64Value(int64(instance.GPUMemory.SizeInGigabytes)),
-		},
-	)
+	gpuMemoryObj, gpuMemDiags := flattenInstanceMemory(instance.GPUMemory)
 	diagnostics.Append(gpuMemDiags...)
 	data.GPUMemory = gpuMemoryObj
 
-	memoryObj, memDiags := types.ObjectValue(
-		map[string]attr.Type{
-			"description":       types.StringType,
-			"size_in_gigabytes": types.Int64Type,
-		},
-		map[string]attr.Value{
-			"description":       types.StringValue(instance.Memory.Description),
-			"size_in_gigabytes": types.Int64Value(int64(instance.Memory.SizeInGigabytes)),
-		},
-	)
+	memoryObj, memDiags := flattenInstanceMemory(instance.Memory)
 	diagnostics.Append(memDiags...)
 	data.Memory = memoryObj
 
-	storageObj, storDiags := types.ObjectValue(
-		map[string]attr.Type{
-			"description": types.StringType,
-		},
-		map[string]attr.Value{
-			"description": types.StringValue(instance.Storage.Description),
-		},
-	)
+	storageObj, storDiags := flattenInstanceStorage(instance.Storage)
 	diagnostics.Append(storDiags...)
 	data.Storage = storageObj
 }
 
+const (
+	// defaultInstanceLocation is the location the API uses when none is given
+	defaultInstanceLocation = "FIN-01"
+
+	capacityCheckWarn  = "warn"
+	capacityCheckError = "error"
+
+	// Hibernation is not offered as a desired status: the API deletes a hibernated instance
+	// and detaches its volumes, and has no action to resume it
+	desiredStatusRunning  = "running"
+	desiredStatusShutdown = "shutdown"
+)
+
+// Attribute types of the nested hardware objects shared by verda_instance and
+// the instance type data sources
+var (
+	cpuAttrTypes = map[string]attr.Type{
+		"description":     types.StringType,
+		"number_of_cores": types.Int64Type,
+	}
+
+	gpuAttrTypes = map[string]attr.Type{
+		"description":    types.StringType,
+		"number_of_gpus": types.Int64Type,
+	}
+
+	memoryAttrTypes = map[string]attr.Type{
+		"description":       types.StringType,
+		"size_in_gigabytes": types.Int64Type,
+	}
+
+	storageAttrTypes = map[string]attr.Type{
+		"description": types.StringType,
+	}
+)
+
+func flattenInstanceCPU(cpu verda.InstanceCPU) (types.Object, diag.Diagnostics) {
+	return types.ObjectValue(cpuAttrTypes, map[string]attr.Value{
+		"description":     types.StringValue(cpu.Description),
+		"number_of_cores": types.Int64Value(int64(cpu.NumberOfCores)),
+	})
+}
+
+func flattenInstanceGPU(gpu verda.InstanceGPU) (types.Object, diag.Diagnostics) {
+	return types.ObjectValue(gpuAttrTypes, map[string]attr.Value{
+		"description":    types.StringValue(gpu.Description),
+		"number_of_gpus": types.Int64Value(int64(gpu.NumberOfGPUs)),
+	})
+}
+
+func flattenInstanceMemory(memory verda.InstanceMemory) (types.Object, diag.Diagnostics) {
+	return types.ObjectValue(memoryAttrTypes, map[string]attr.Value{
+		"description":       types.StringValue(memory.Description),
+		"size_in_gigabytes": types.Int64Value(int64(memory.SizeInGigabytes)),
+	})
+}
+
+func flattenInstanceStorage(storage verda.InstanceStorage) (types.Object, diag.Diagnostics) {
+	return types.ObjectValue(storageAttrTypes, map[string]attr.Value{
+		"description": types.StringValue(storage.Description),
+	})
+}
+
 // Custom plan modifier for bool default value
 type boolDefaultModifier struct {
 	defaultValue bool
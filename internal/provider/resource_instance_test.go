@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda"
+)
+
+func TestSetPowerState(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        string
+		desiredStatus string
+		wantActions   []string
+		wantStatus    string
+		wantErr       bool
+	}{
+		{name: "running to shutdown", status: verda.StatusRunning, desiredStatus: desiredStatusShutdown, wantActions: []string{verda.ActionShutdown}, wantStatus: verda.StatusOffline},
+		{name: "offline to running", status: verda.StatusOffline, desiredStatus: desiredStatusRunning, wantActions: []string{verda.ActionStart}, wantStatus: verda.StatusRunning},
+		{name: "already running", status: verda.StatusRunning, desiredStatus: desiredStatusRunning, wantStatus: verda.StatusRunning},
+		{name: "already shut down", status: verda.StatusOffline, desiredStatus: desiredStatusShutdown, wantStatus: verda.StatusOffline},
+		// Hibernating deletes the instance, so it is never sent
+		{name: "running to hibernated", status: verda.StatusRunning, desiredStatus: "hibernated", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := tt.status
+			var gotActions []string
+			client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/instances/instance-id":
+					_ = json.NewEncoder(w).Encode(verda.Instance{ID: "instance-id", Status: status})
+				case r.Method == http.MethodPut && r.URL.Path == "/instances":
+					var actionReq verda.InstanceActionRequest
+					if err := json.NewDecoder(r.Body).Decode(&actionReq); err != nil {
+						t.Errorf("decoding instance action: %v", err)
+					}
+					gotActions = append(gotActions, actionReq.Action)
+
+					switch actionReq.Action {
+					case verda.ActionStart:
+						status = verda.StatusRunning
+					case verda.ActionShutdown:
+						status = verda.StatusOffline
+					}
+					w.WriteHeader(http.StatusAccepted)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+
+			r := &InstanceResource{client: client}
+			instance, err := r.setPowerState(context.Background(), "instance-id", tt.desiredStatus)
+
+			if !slices.Equal(gotActions, tt.wantActions) {
+				t.Errorf("actions = %v, want %v", gotActions, tt.wantActions)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("setPowerState() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && instance.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", instance.Status, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -1,14 +1,20 @@
 package provider
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -29,13 +35,16 @@ type ServerlessJobResource struct {
 }
 
 type ServerlessJobResourceModel struct {
-	Name                      types.String `tfsdk:"name"`
-	Compute                   types.Object `tfsdk:"compute"`
-	Scaling                   types.Object `tfsdk:"scaling"`
-	ContainerRegistrySettings types.Object `tfsdk:"container_registry_settings"`
-	Containers                types.List   `tfsdk:"containers"`
-	EndpointBaseURL           types.String `tfsdk:"endpoint_base_url"`
-	CreatedAt                 types.String `tfsdk:"created_at"`
+	Name                      types.String   `tfsdk:"name"`
+	Compute                   types.Object   `tfsdk:"compute"`
+	Scaling                   types.Object   `tfsdk:"scaling"`
+	ContainerRegistrySettings types.Object   `tfsdk:"container_registry_settings"`
+	Containers                types.List     `tfsdk:"containers"`
+	EndpointBaseURL           types.String   `tfsdk:"endpoint_base_url"`
+	CreatedAt                 types.String   `tfsdk:"created_at"`
+	SensitiveEnvWOVersion     types.Int64    `tfsdk:"sensitive_env_wo_version"`
+	DeletionProtection        types.Bool     `tfsdk:"deletion_protection"`
+	Timeouts                  timeouts.Value `tfsdk:"timeouts"`
 }
 
 type JobScalingModel struct {
@@ -96,16 +105,25 @@ func (r *ServerlessJobResource) Schema(ctx context.Context, req resource.SchemaR
 				MarkdownDescription: "Container registry authentication settings",
 				Optional:            true,
 				Computed:            true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
 				Attributes: map[string]schema.Attribute{
 					"is_private": schema.StringAttribute{
 						MarkdownDescription: "Whether the registry is private ('true' or 'false')",
 						Optional:            true,
 						Computed:            true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
 					},
 					"credentials": schema.StringAttribute{
 						MarkdownDescription: "Name of the registry credentials resource",
 						Optional:            true,
 						Computed:            true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
 					},
 				},
 			},
@@ -180,6 +198,38 @@ func (r *ServerlessJobResource) Schema(ctx context.Context, req resource.SchemaR
 								},
 							},
 						},
+						"sensitive_env": schema.ListNestedAttribute{
+							MarkdownDescription: "Environment variables with sensitive values, such as API keys. They are merged with `env` and redacted in plan output. " +
+								"Their values are never read back from the API, but values set in `value_or_reference_to_secret` are stored in the state; " +
+								"set `value_wo` instead to keep them out of the state.",
+							Optional:  true,
+							Sensitive: true,
+							NestedObject: schema.NestedAttributeObject{
+								Validators: []validator.Object{
+									sensitiveEnvValueValidator{},
+								},
+								Attributes: map[string]schema.Attribute{
+									"type": schema.StringAttribute{
+										MarkdownDescription: "Type of environment variable ('plain' or 'secret')",
+										Required:            true,
+									},
+									"name": schema.StringAttribute{
+										MarkdownDescription: "Name of the environment variable",
+										Required:            true,
+									},
+									"value_or_reference_to_secret": schema.StringAttribute{
+										MarkdownDescription: "Value for plain env vars or secret name for secret env vars, stored in the state. Conflicts with `value_wo`.",
+										Optional:            true,
+									},
+									"value_wo": schema.StringAttribute{
+										MarkdownDescription: "Write-only variant of `value_or_reference_to_secret`, which is never stored in the state. " +
+											"Requires Terraform 1.11 or later. Change `sensitive_env_wo_version` to apply a new value.",
+										Optional:  true,
+										WriteOnly: true,
+									},
+								},
+							},
+						},
 						"volume_mounts": schema.ListNestedAttribute{
 							MarkdownDescription: "Volume mounts for the container",
 							Optional:            true,
@@ -217,11 +267,27 @@ func (r *ServerlessJobResource) Schema(ctx context.Context, req resource.SchemaR
 			"endpoint_base_url": schema.StringAttribute{
 				MarkdownDescription: "Base URL for the job deployment endpoint",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"created_at": schema.StringAttribute{
 				MarkdownDescription: "Timestamp when the job deployment was created",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
+			"sensitive_env_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of the write-only `value_wo` values in `sensitive_env`. Terraform cannot detect changes to write-only values, " +
+					"so change this to send the current values to the API.",
+				Optional: true,
+			},
+			"deletion_protection": deletionProtectionAttribute("serverless job deployment"),
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
 		},
 	}
 }
@@ -254,15 +320,53 @@ func (r *ServerlessJobResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultDeploymentCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	writeOnlyEnv := configWriteOnlyEnv(ctx, req.Config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createReq := r.expandJobDeploymentRequest(ctx, &data, writeOnlyEnv, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deployment, err := r.client.ServerlessJobs.CreateJobDeployment(ctx, createReq)
+	if err != nil {
+		deployment = r.adoptJobDeployment(ctx, &data, err, &resp.Diagnostics)
+		if deployment == nil {
+			return
+		}
+	}
+
+	// Flatten API response, merging with plan to preserve fields the API doesn't return
+	planContainers := data.Containers
+	r.flattenJobDeploymentToModel(ctx, deployment, &data, &resp.Diagnostics)
+	r.mergeJobContainersFromPlan(ctx, planContainers, &data, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// expandJobDeploymentRequest builds the job deployment request from the plan. It is used for
+// both creating and updating the job deployment.
+func (r *ServerlessJobResource) expandJobDeploymentRequest(ctx context.Context, data *ServerlessJobResourceModel, writeOnlyEnv map[int]map[string]string, diagnostics *diag.Diagnostics) *verda.CreateJobDeploymentRequest {
 	createReq := &verda.CreateJobDeploymentRequest{
 		Name: data.Name.ValueString(),
 	}
 
 	// Parse compute
 	var compute ComputeModel
-	resp.Diagnostics.Append(data.Compute.As(ctx, &compute, basetypes.ObjectAsOptions{})...)
-	if resp.Diagnostics.HasError() {
-		return
+	diagnostics.Append(data.Compute.As(ctx, &compute, basetypes.ObjectAsOptions{})...)
+	if diagnostics.HasError() {
+		return nil
 	}
 
 	createReq.Compute = &verda.ContainerCompute{
@@ -271,9 +375,9 @@ func (r *ServerlessJobResource) Create(ctx context.Context, req resource.CreateR
 	}
 
 	var scaling JobScalingModel
-	resp.Diagnostics.Append(data.Scaling.As(ctx, &scaling, basetypes.ObjectAsOptions{})...)
-	if resp.Diagnostics.HasError() {
-		return
+	diagnostics.Append(data.Scaling.As(ctx, &scaling, basetypes.ObjectAsOptions{})...)
+	if diagnostics.HasError() {
+		return nil
 	}
 
 	scalingOptions := verda.JobScalingOptions{
@@ -287,9 +391,9 @@ func (r *ServerlessJobResource) Create(ctx context.Context, req resource.CreateR
 	// Parse container registry settings if provided
 	if !data.ContainerRegistrySettings.IsNull() && !data.ContainerRegistrySettings.IsUnknown() {
 		var registrySettings RegistrySettingsModel
-		resp.Diagnostics.Append(data.ContainerRegistrySettings.As(ctx, &registrySettings, basetypes.ObjectAsOptions{})...)
-		if resp.Diagnostics.HasError() {
-			return
+		diagnostics.Append(data.ContainerRegistrySettings.As(ctx, &registrySettings, basetypes.ObjectAsOptions{})...)
+		if diagnostics.HasError() {
+			return nil
 		}
 
 		isPrivate := registrySettings.IsPrivate.ValueString() == "true"
@@ -310,13 +414,13 @@ func (r *ServerlessJobResource) Create(ctx context.Context, req resource.CreateR
 
 	// Parse containers
 	var containers []ContainerModel
-	resp.Diagnostics.Append(data.Containers.ElementsAs(ctx, &containers, false)...)
-	if resp.Diagnostics.HasError() {
-		return
+	diagnostics.Append(data.Containers.ElementsAs(ctx, &containers, false)...)
+	if diagnostics.HasError() {
+		return nil
 	}
 
 	var deploymentContainers []verda.CreateDeploymentContainer
-	for _, container := range containers {
+	for i, container := range containers {
 		deploymentContainer := verda.CreateDeploymentContainer{
 			Image:       container.Image.ValueString(),
 			ExposedPort: int(container.ExposedPort.ValueInt64()),
@@ -325,9 +429,9 @@ func (r *ServerlessJobResource) Create(ctx context.Context, req resource.CreateR
 		// Parse healthcheck if provided
 		if !container.Healthcheck.IsNull() {
 			var healthcheck HealthcheckModel
-			resp.Diagnostics.Append(container.Healthcheck.As(ctx, &healthcheck, basetypes.ObjectAsOptions{})...)
-			if resp.Diagnostics.HasError() {
-				return
+			diagnostics.Append(container.Healthcheck.As(ctx, &healthcheck, basetypes.ObjectAsOptions{})...)
+			if diagnostics.HasError() {
+				return nil
 			}
 
 			enabled := healthcheck.Enabled.ValueString() == "true"
@@ -353,9 +457,9 @@ func (r *ServerlessJobResource) Create(ctx context.Context, req resource.CreateR
 		// Parse entrypoint overrides if provided
 		if !container.EntrypointOverrides.IsNull() && !container.EntrypointOverrides.IsUnknown() {
 			var entrypointOverrides EntrypointOverridesModel
-			resp.Diagnostics.Append(container.EntrypointOverrides.As(ctx, &entrypointOverrides, basetypes.ObjectAsOptions{})...)
-			if resp.Diagnostics.HasError() {
-				return
+			diagnostics.Append(container.EntrypointOverrides.As(ctx, &entrypointOverrides, basetypes.ObjectAsOptions{})...)
+			if diagnostics.HasError() {
+				return nil
 			}
 
 			overrides := &verda.ContainerEntrypointOverrides{
@@ -364,18 +468,18 @@ func (r *ServerlessJobResource) Create(ctx context.Context, req resource.CreateR
 
 			if !entrypointOverrides.Entrypoint.IsNull() && !entrypointOverrides.Entrypoint.IsUnknown() {
 				var entrypoint []string
-				resp.Diagnostics.Append(entrypointOverrides.Entrypoint.ElementsAs(ctx, &entrypoint, false)...)
-				if resp.Diagnostics.HasError() {
-					return
+				diagnostics.Append(entrypointOverrides.Entrypoint.ElementsAs(ctx, &entrypoint, false)...)
+				if diagnostics.HasError() {
+					return nil
 				}
 				overrides.Entrypoint = entrypoint
 			}
 
 			if !entrypointOverrides.Cmd.IsNull() && !entrypointOverrides.Cmd.IsUnknown() {
 				var cmd []string
-				resp.Diagnostics.Append(entrypointOverrides.Cmd.ElementsAs(ctx, &cmd, false)...)
-				if resp.Diagnostics.HasError() {
-					return
+				diagnostics.Append(entrypointOverrides.Cmd.ElementsAs(ctx, &cmd, false)...)
+				if diagnostics.HasError() {
+					return nil
 				}
 				overrides.Cmd = cmd
 			}
@@ -386,9 +490,9 @@ func (r *ServerlessJobResource) Create(ctx context.Context, req resource.CreateR
 		// Parse environment variables if provided
 		if !container.Env.IsNull() {
 			var envVars []EnvVarModel
-			resp.Diagnostics.Append(container.Env.ElementsAs(ctx, &envVars, false)...)
-			if resp.Diagnostics.HasError() {
-				return
+			diagnostics.Append(container.Env.ElementsAs(ctx, &envVars, false)...)
+			if diagnostics.HasError() {
+				return nil
 			}
 
 			var containerEnvVars []verda.ContainerEnvVar
@@ -402,12 +506,18 @@ func (r *ServerlessJobResource) Create(ctx context.Context, req resource.CreateR
 			deploymentContainer.Env = containerEnvVars
 		}
 
+		// Sensitive environment variables are sent together with the other ones
+		deploymentContainer.Env = append(deploymentContainer.Env, expandSensitiveEnv(ctx, container, writeOnlyEnv[i], diagnostics)...)
+		if diagnostics.HasError() {
+			return nil
+		}
+
 		// Parse volume mounts if provided
 		if !container.VolumeMounts.IsNull() {
 			var volumeMounts []VolumeMountModel
-			resp.Diagnostics.Append(container.VolumeMounts.ElementsAs(ctx, &volumeMounts, false)...)
-			if resp.Diagnostics.HasError() {
-				return
+			diagnostics.Append(container.VolumeMounts.ElementsAs(ctx, &volumeMounts, false)...)
+			if diagnostics.HasError() {
+				return nil
 			}
 
 			var containerVolumeMounts []verda.ContainerVolumeMount
@@ -439,18 +549,7 @@ func (r *ServerlessJobResource) Create(ctx context.Context, req resource.CreateR
 
 	createReq.Containers = deploymentContainers
 
-	deployment, err := r.client.ServerlessJobs.CreateJobDeployment(ctx, createReq)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create serverless job deployment, got error: %s", err))
-		return
-	}
-
-	// Flatten API response, merging with plan to preserve fields the API doesn't return
-	planContainers := data.Containers
-	r.flattenJobDeploymentToModel(ctx, deployment, &data, &resp.Diagnostics)
-	r.mergeJobContainersFromPlan(ctx, planContainers, &data, &resp.Diagnostics)
-
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	return createReq
 }
 
 func (r *ServerlessJobResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -462,8 +561,22 @@ func (r *ServerlessJobResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	deployment, err := r.client.ServerlessJobs.GetJobDeploymentByName(ctx, data.Name.ValueString())
 	if err != nil {
+		if isNotFoundError(err) {
+			// The serverless job deployment was deleted outside of Terraform, so plan to recreate it
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read serverless job deployment, got error: %s", err))
 		return
 	}
@@ -482,17 +595,89 @@ func (r *ServerlessJobResource) Read(ctx context.Context, req resource.ReadReque
 
 func (r *ServerlessJobResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data ServerlessJobResourceModel
+	var state ServerlessJobResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	resp.Diagnostics.AddError(
-		"Update Not Supported",
-		"Serverless job deployments cannot be updated. Please delete and recreate the resource with new values.",
-	)
+	settingsOnly, err := planChangesOnly(req.Plan, req.State, "deletion_protection", "timeouts")
+	if err != nil {
+		resp.Diagnostics.AddError("Internal Error", fmt.Sprintf("Unable to compare plan with state, got error: %s", err))
+		return
+	}
+
+	if settingsOnly {
+		// Only provider-side settings changed, which are not sent to the API
+		state.DeletionProtection = data.DeletionProtection
+		state.Timeouts = data.Timeouts
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultDeploymentUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	writeOnlyEnv := configWriteOnlyEnv(ctx, req.Config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	expanded := r.expandJobDeploymentRequest(ctx, &data, writeOnlyEnv, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only send the parts that changed, so the job deployment keeps its queue and running jobs
+	updateReq := &verda.UpdateJobDeploymentRequest{}
+	if !data.Compute.Equal(state.Compute) {
+		updateReq.Compute = expanded.Compute
+	}
+	if !data.Scaling.Equal(state.Scaling) {
+		updateReq.Scaling = expanded.Scaling
+	}
+	if !data.ContainerRegistrySettings.Equal(state.ContainerRegistrySettings) {
+		updateReq.ContainerRegistrySettings = expanded.ContainerRegistrySettings
+	}
+	// Write-only values cannot be compared, so a new version sends the containers again
+	if !data.Containers.Equal(state.Containers) || !data.SensitiveEnvWOVersion.Equal(state.SensitiveEnvWOVersion) {
+		updateReq.Containers = expanded.Containers
+	}
+
+	if err := r.updateJobDeployment(ctx, data.Name.ValueString(), updateReq); err != nil {
+		if isNotFoundError(err) {
+			resp.Diagnostics.AddError(
+				"Serverless Job Deployment Not Found",
+				fmt.Sprintf("Serverless job deployment %s was deleted outside of Terraform. Plan again to recreate it.", data.Name.ValueString()),
+			)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update serverless job deployment, got error: %s", err))
+		return
+	}
+
+	deployment, err := r.client.ServerlessJobs.GetJobDeploymentByName(ctx, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read serverless job deployment after update, got error: %s", err))
+		return
+	}
+
+	// Flatten API response, merging with plan to preserve fields the API doesn't return
+	planContainers := data.Containers
+	r.flattenJobDeploymentToModel(ctx, deployment, &data, &resp.Diagnostics)
+	r.mergeJobContainersFromPlan(ctx, planContainers, &data, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ServerlessJobResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -504,8 +689,22 @@ func (r *ServerlessJobResource) Delete(ctx context.Context, req resource.DeleteR
 		return
 	}
 
-	err := r.client.ServerlessJobs.DeleteJobDeployment(ctx, data.Name.ValueString(), 300000)
-	if err != nil {
+	if checkDeletionProtection(data.DeletionProtection, "serverless job deployment", data.Name.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeploymentDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.client.ServerlessJobs.DeleteJobDeployment(ctx, data.Name.ValueString(), deadlineMilliseconds(ctx, 300000))
+	// Already deleted outside of Terraform
+	if err != nil && !isNotFoundError(err) {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete serverless job deployment, got error: %s", err))
 		return
 	}
@@ -515,6 +714,80 @@ func (r *ServerlessJobResource) ImportState(ctx context.Context, req resource.Im
 	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
 }
 
+// updateJobDeployment applies a partial update to the job deployment with PATCH
+// /job-deployments/{name}. verdacloud-sdk-go v1.2.1 defines UpdateJobDeploymentRequest but no
+// method to send it, so the request is built here until the SDK adds one. The client reports
+// non-2xx responses as *verda.APIError, the same as for the SDK methods, so isNotFoundError works.
+func (r *ServerlessJobResource) updateJobDeployment(ctx context.Context, jobName string, updateReq *verda.UpdateJobDeploymentRequest) error {
+	body, err := json.Marshal(updateReq)
+	if err != nil {
+		return err
+	}
+
+	httpReq, err := r.client.NewRequest(ctx, http.MethodPatch, "/job-deployments/"+url.PathEscape(jobName), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	// The response body is not needed, the deployment is read back after the update
+	_, err = r.client.Do(httpReq, nil)
+	return err
+}
+
+// adoptJobDeployment looks for a job deployment left behind by an ambiguous create failure, such
+// as a timeout, a dropped connection or a server error. It returns the deployment if its full
+// configuration matches the plan, or adds an error and returns nil.
+func (r *ServerlessJobResource) adoptJobDeployment(ctx context.Context, data *ServerlessJobResourceModel, createErr error, diagnostics *diag.Diagnostics) *verda.JobDeployment {
+	createError := fmt.Sprintf("Unable to create serverless job deployment, got error: %s", createErr)
+
+	if !isAmbiguousCreateError(createErr) {
+		diagnostics.AddError("Client Error", createError)
+		return nil
+	}
+
+	ctx, cancel := ambiguousCreateLookupContext(ctx)
+	defer cancel()
+
+	jobName := data.Name.ValueString()
+	deployment, err := r.client.ServerlessJobs.GetJobDeploymentByName(ctx, jobName)
+	if err != nil {
+		// Nothing was created, or it cannot be checked, so report the original error
+		diagnostics.AddError("Client Error", createError)
+		return nil
+	}
+
+	// Read the existing deployment the same way as a refresh would, so it can be compared with the plan.
+	// Fields the API does not return, such as sensitive_env values, cannot be compared and are taken from the plan.
+	existing := *data
+	r.flattenJobDeploymentToModel(ctx, deployment, &existing, diagnostics)
+	r.mergeJobContainersFromPlan(ctx, data.Containers, &existing, diagnostics)
+	if diagnostics.HasError() {
+		return nil
+	}
+
+	matches := len(deployment.Containers) == len(data.Containers.Elements()) &&
+		existing.Compute.Equal(data.Compute) &&
+		existing.Scaling.Equal(data.Scaling) &&
+		existing.ContainerRegistrySettings.Equal(data.ContainerRegistrySettings) &&
+		existing.Containers.Equal(data.Containers)
+
+	if !matches {
+		diagnostics.AddError(
+			"Serverless Job Deployment Already Exists",
+			fmt.Sprintf("%s\n\nA serverless job deployment named %s already exists, but its configuration differs from the plan. "+
+				"Import it with 'terraform import' or choose another name.", createError, jobName),
+		)
+		return nil
+	}
+
+	diagnostics.AddWarning(
+		"Adopted Existing Serverless Job Deployment",
+		fmt.Sprintf("Creating serverless job deployment %s failed with: %s\n\nA deployment with this name and the planned configuration exists, "+
+			"probably created by that request, so it was adopted into the state.", jobName, createErr),
+	)
+	return deployment
+}
+
 func (r *ServerlessJobResource) flattenJobDeploymentToModel(ctx context.Context, deployment *verda.JobDeployment, data *ServerlessJobResourceModel, diagnostics *diag.Diagnostics) {
 	data.Name = types.StringValue(deployment.Name)
 	data.EndpointBaseURL = types.StringValue(deployment.EndpointBaseURL)
@@ -794,6 +1067,11 @@ func (r *ServerlessJobResource) flattenJobContainersToModel(ctx context.Context,
 					},
 				},
 			},
+			"sensitive_env": types.ListType{
+				ElemType: types.ObjectType{
+					AttrTypes: sensitiveEnvVarAttrTypes,
+				},
+			},
 			"volume_mounts": types.ListType{
 				ElemType: types.ObjectType{
 					AttrTypes: map[string]attr.Type{
@@ -813,6 +1091,7 @@ func (r *ServerlessJobResource) flattenJobContainersToModel(ctx context.Context,
 			"healthcheck":          healthcheckObj,
 			"entrypoint_overrides": entrypointOverridesObj,
 			"env":                  envList,
+			"sensitive_env":        types.ListNull(types.ObjectType{AttrTypes: sensitiveEnvVarAttrTypes}),
 			"volume_mounts":        volumeMountsList,
 		}
 
@@ -850,6 +1129,11 @@ func (r *ServerlessJobResource) flattenJobContainersToModel(ctx context.Context,
 						},
 					},
 				},
+				"sensitive_env": types.ListType{
+					ElemType: types.ObjectType{
+						AttrTypes: sensitiveEnvVarAttrTypes,
+					},
+				},
 				"volume_mounts": types.ListType{
 					ElemType: types.ObjectType{
 						AttrTypes: map[string]attr.Type{
@@ -910,6 +1194,9 @@ func (r *ServerlessJobResource) mergeJobContainersFromPlan(ctx context.Context,
 		mergedContainer := apiContainer
 		mergedContainer.VolumeMounts = planContainer.VolumeMounts
 
+		// Keep sensitive values out of env and take them from the plan, never from the API
+		mergedContainer.Env, mergedContainer.SensitiveEnv = separateSensitiveEnv(ctx, apiContainer.Env, planContainer.SensitiveEnv, diagnostics)
+
 		// Preserve entrypoint_overrides from plan if API didn't return it
 		if (apiContainer.EntrypointOverrides.IsNull() || apiContainer.EntrypointOverrides.IsUnknown()) &&
 			!planContainer.EntrypointOverrides.IsNull() {
@@ -943,6 +1230,11 @@ func (r *ServerlessJobResource) mergeJobContainersFromPlan(ctx context.Context,
 					},
 				},
 			},
+			"sensitive_env": types.ListType{
+				ElemType: types.ObjectType{
+					AttrTypes: sensitiveEnvVarAttrTypes,
+				},
+			},
 			"volume_mounts": types.ListType{
 				ElemType: types.ObjectType{
 					AttrTypes: map[string]attr.Type{
@@ -962,6 +1254,7 @@ func (r *ServerlessJobResource) mergeJobContainersFromPlan(ctx context.Context,
 			"healthcheck":          mergedContainer.Healthcheck,
 			"entrypoint_overrides": mergedContainer.EntrypointOverrides,
 			"env":                  mergedContainer.Env,
+			"sensitive_env":        mergedContainer.SensitiveEnv,
 			"volume_mounts":        mergedContainer.VolumeMounts,
 		}
 
@@ -999,6 +1292,11 @@ func (r *ServerlessJobResource) mergeJobContainersFromPlan(ctx context.Context,
 						},
 					},
 				},
+				"sensitive_env": types.ListType{
+					ElemType: types.ObjectType{
+						AttrTypes: sensitiveEnvVarAttrTypes,
+					},
+				},
 				"volume_mounts": types.ListType{
 					ElemType: types.ObjectType{
 						AttrTypes: map[string]attr.Type{
@@ -0,0 +1,223 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda"
+)
+
+// newTestClient returns a client for an httptest server that answers token requests itself
+// and passes all other requests to the handler
+func newTestClient(t *testing.T, handler http.Handler) *verda.Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isTokenRequest(r) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"access_token":"token","token_type":"Bearer","expires_in":3600}`))
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := verda.NewClient(
+		verda.WithBaseURL(server.URL),
+		verda.WithClientID("client-id"),
+		verda.WithClientSecret("client-secret"),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	return client
+}
+
+func TestUpdateJobDeployment(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		body         string
+		wantErr      bool
+		wantNotFound bool
+	}{
+		{name: "ok with body", status: http.StatusOK, body: `{"name":"job"}`},
+		{name: "ok without body", status: http.StatusOK},
+		{name: "accepted", status: http.StatusAccepted},
+		{name: "not found", status: http.StatusNotFound, body: `{"code":"not_found","message":"deployment not found"}`, wantErr: true, wantNotFound: true},
+		{name: "bad request", status: http.StatusBadRequest, body: `{"code":"invalid_request","message":"invalid scaling"}`, wantErr: true},
+		{name: "server error without JSON", status: http.StatusInternalServerError, body: "internal error", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotMethod, gotPath string
+			var gotBody verda.UpdateJobDeploymentRequest
+			client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotMethod = r.Method
+				gotPath = r.URL.Path
+				body, _ := io.ReadAll(r.Body)
+				_ = json.Unmarshal(body, &gotBody)
+
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+
+			r := &ServerlessJobResource{client: client}
+			updateReq := &verda.UpdateJobDeploymentRequest{
+				Scaling: &verda.JobScalingOptions{MaxReplicaCount: 3},
+			}
+			err := r.updateJobDeployment(context.Background(), "my job", updateReq)
+
+			if gotMethod != http.MethodPatch || gotPath != "/job-deployments/my job" {
+				t.Errorf("request = %s %s, want PATCH /job-deployments/my job", gotMethod, gotPath)
+			}
+			if gotBody.Scaling == nil || gotBody.Scaling.MaxReplicaCount != 3 || gotBody.Containers != nil {
+				t.Errorf("request body = %+v, want only scaling with max_replica_count 3", gotBody)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("updateJobDeployment() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				return
+			}
+
+			var apiErr *verda.APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status {
+				t.Errorf("updateJobDeployment() error = %#v, want *verda.APIError with status %d", err, tt.status)
+			}
+			if got := isNotFoundError(err); got != tt.wantNotFound {
+				t.Errorf("isNotFoundError() = %v, want %v", got, tt.wantNotFound)
+			}
+		})
+	}
+}
+
+func TestServerlessJobCreateAdoptsAfterTimeout(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	NewServerlessJobResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("Schema() diagnostics = %v", schemaResp.Diagnostics)
+	}
+
+	tests := []struct {
+		name        string
+		exists      bool
+		maxReplicas int
+		wantError   string
+		wantWarning string
+	}{
+		{name: "job deployment matches the plan", exists: true, maxReplicas: 3, wantWarning: "Adopted Existing Serverless Job Deployment"},
+		{name: "job deployment differs from the plan", exists: true, maxReplicas: 1, wantError: "Serverless Job Deployment Already Exists"},
+		{name: "job deployment was not created", exists: false, wantError: "Client Error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			release := make(chan struct{})
+			client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+
+				switch {
+				case r.Method == http.MethodPost && r.URL.Path == "/job-deployments":
+					// Answer only after the client gave up, as a create that outlives the create timeout
+					select {
+					case <-r.Context().Done():
+					case <-release:
+					}
+				case !tt.exists:
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"code":"not_found","message":"deployment not found"}`))
+				case r.Method == http.MethodGet && r.URL.Path == "/job-deployments/job":
+					_ = json.NewEncoder(w).Encode(verda.JobDeployment{
+						Name:            "job",
+						EndpointBaseURL: "https://job.example.com",
+						Compute:         &verda.ContainerCompute{Name: "H100", Size: 1},
+						Scaling:         &verda.JobScalingOptions{MaxReplicaCount: tt.maxReplicas, QueueMessageTTLSeconds: 300, DeadlineSeconds: 600},
+						Containers: []verda.DeploymentContainer{{
+							Image:       verda.ContainerImage{Image: "registry.example.com/job:1.0"},
+							ExposedPort: 8080,
+						}},
+					})
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+
+			// Release the create request before the server is closed
+			t.Cleanup(func() { close(release) })
+
+			plan := tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}
+			var diags diag.Diagnostics
+			diags.Append(plan.SetAttribute(ctx, path.Root("name"), "job")...)
+			diags.Append(plan.SetAttribute(ctx, path.Root("compute").AtName("name"), "H100")...)
+			diags.Append(plan.SetAttribute(ctx, path.Root("compute").AtName("size"), 1)...)
+			diags.Append(plan.SetAttribute(ctx, path.Root("scaling").AtName("max_replica_count"), 3)...)
+			diags.Append(plan.SetAttribute(ctx, path.Root("scaling").AtName("queue_message_ttl_seconds"), 300)...)
+			diags.Append(plan.SetAttribute(ctx, path.Root("scaling").AtName("deadline_seconds"), 600)...)
+			diags.Append(plan.SetAttribute(ctx, path.Root("containers").AtListIndex(0).AtName("image"), "registry.example.com/job:1.0")...)
+			diags.Append(plan.SetAttribute(ctx, path.Root("containers").AtListIndex(0).AtName("exposed_port"), 8080)...)
+			diags.Append(plan.SetAttribute(ctx, path.Root("timeouts").AtName("create"), "100ms")...)
+			if diags.HasError() {
+				t.Fatalf("SetAttribute() diagnostics = %v", diags)
+			}
+
+			req := resource.CreateRequest{
+				Plan:   tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+				Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+			}
+			resp := resource.CreateResponse{State: tfsdk.State{
+				Schema: plan.Schema,
+				Raw:    tftypes.NewValue(plan.Schema.Type().TerraformType(ctx), nil),
+			}}
+
+			r := &ServerlessJobResource{client: client}
+			r.Create(ctx, req, &resp)
+
+			var gotErrors, gotWarnings []string
+			for _, d := range resp.Diagnostics.Errors() {
+				gotErrors = append(gotErrors, d.Summary())
+			}
+			for _, d := range resp.Diagnostics.Warnings() {
+				gotWarnings = append(gotWarnings, d.Summary())
+			}
+			if tt.wantError == "" && len(gotErrors) > 0 || tt.wantError != "" && !slices.Equal(gotErrors, []string{tt.wantError}) {
+				t.Fatalf("errors = %v, want %q", resp.Diagnostics.Errors(), tt.wantError)
+			}
+			if tt.wantWarning != "" && !slices.Equal(gotWarnings, []string{tt.wantWarning}) {
+				t.Errorf("warnings = %v, want %q", gotWarnings, tt.wantWarning)
+			}
+			if tt.wantError != "" {
+				return
+			}
+
+			var gotEndpoint types.String
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("endpoint_base_url"), &gotEndpoint)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("GetAttribute() diagnostics = %v", resp.Diagnostics)
+			}
+			if gotEndpoint.ValueString() != "https://job.example.com" {
+				t.Errorf("endpoint_base_url = %s, want https://job.example.com", gotEndpoint)
+			}
+		})
+	}
+}
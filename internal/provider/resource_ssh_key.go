@@ -3,7 +3,9 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -25,11 +27,12 @@ type SSHKeyResource struct {
 }
 
 type SSHKeyResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	PublicKey   types.String `tfsdk:"public_key"`
-	Fingerprint types.String `tfsdk:"fingerprint"`
-	CreatedAt   types.String `tfsdk:"created_at"`
+	ID          types.String   `tfsdk:"id"`
+	Name        types.String   `tfsdk:"name"`
+	PublicKey   types.String   `tfsdk:"public_key"`
+	Fingerprint types.String   `tfsdk:"fingerprint"`
+	CreatedAt   types.String   `tfsdk:"created_at"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *SSHKeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -68,6 +71,10 @@ func (r *SSHKeyResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				MarkdownDescription: "Creation timestamp",
 			},
 		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
 	}
 }
 
@@ -99,6 +106,15 @@ func (r *SSHKeyResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	createReq := verda.CreateSSHKeyRequest{
 		Name:      data.Name.ValueString(),
 		PublicKey: data.PublicKey.ValueString(),
@@ -126,12 +142,32 @@ func (r *SSHKeyResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	sshKey, err := r.client.SSHKeys.GetSSHKeyByID(ctx, data.ID.ValueString())
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	// Read from the list, which is shared by all SSH keys during a refresh
+	sshKeys, err := r.client.SSHKeys.GetAllSSHKeys(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read SSH key, got error: %s", err))
 		return
 	}
 
+	index := slices.IndexFunc(sshKeys, func(key verda.SSHKey) bool {
+		return key.ID == data.ID.ValueString()
+	})
+	if index < 0 {
+		// The SSH key was deleted outside of Terraform, so plan to recreate it
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	sshKey := sshKeys[index]
+
 	data.Name = types.StringValue(sshKey.Name)
 	data.PublicKey = types.StringValue(sshKey.PublicKey)
 	data.Fingerprint = types.StringValue(sshKey.Fingerprint)
@@ -142,18 +178,34 @@ func (r *SSHKeyResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 func (r *SSHKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data SSHKeyResourceModel
+	var state SSHKeyResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	timeoutsOnly, err := planChangesOnly(req.Plan, req.State, "timeouts")
+	if err != nil {
+		resp.Diagnostics.AddError("Internal Error", fmt.Sprintf("Unable to compare plan with state, got error: %s", err))
+		return
+	}
+
 	// SSH keys cannot be updated in the Verda API, only deleted and recreated
-	resp.Diagnostics.AddError(
-		"Update Not Supported",
-		"SSH keys cannot be updated. Please delete and recreate the resource.",
-	)
+	if !timeoutsOnly {
+		resp.Diagnostics.AddError(
+			"Update Not Supported",
+			"SSH keys cannot be updated. Please delete and recreate the resource.",
+		)
+		return
+	}
+
+	// Only the timeouts changed, which are not sent to the API
+	state.Timeouts = data.Timeouts
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 func (r *SSHKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -165,8 +217,18 @@ func (r *SSHKeyResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	err := r.client.SSHKeys.DeleteSSHKey(ctx, data.ID.ValueString())
-	if err != nil {
+	// Already deleted outside of Terraform
+	if err != nil && !isNotFoundError(err) {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete SSH key, got error: %s", err))
 		return
 	}
@@ -3,7 +3,9 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -25,10 +27,11 @@ type StartupScriptResource struct {
 }
 
 type StartupScriptResourceModel struct {
-	ID        types.String `tfsdk:"id"`
-	Name      types.String `tfsdk:"name"`
-	Script    types.String `tfsdk:"script"`
-	CreatedAt types.String `tfsdk:"created_at"`
+	ID        types.String   `tfsdk:"id"`
+	Name      types.String   `tfsdk:"name"`
+	Script    types.String   `tfsdk:"script"`
+	CreatedAt types.String   `tfsdk:"created_at"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *StartupScriptResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -60,6 +63,10 @@ func (r *StartupScriptResource) Schema(ctx context.Context, req resource.SchemaR
 				MarkdownDescription: "Creation timestamp",
 			},
 		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
 	}
 }
 
@@ -91,6 +98,15 @@ func (r *StartupScriptResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	createReq := verda.CreateStartupScriptRequest{
 		Name:   data.Name.ValueString(),
 		Script: data.Script.ValueString(),
@@ -117,12 +133,32 @@ func (r *StartupScriptResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
-	script, err := r.client.StartupScripts.GetStartupScriptByID(ctx, data.ID.ValueString())
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	// Read from the list, which is shared by all startup scripts during a refresh
+	scripts, err := r.client.StartupScripts.GetAllStartupScripts(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read startup script, got error: %s", err))
 		return
 	}
 
+	index := slices.IndexFunc(scripts, func(script verda.StartupScript) bool {
+		return script.ID == data.ID.ValueString()
+	})
+	if index < 0 {
+		// The startup script was deleted outside of Terraform, so plan to recreate it
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	script := scripts[index]
+
 	data.Name = types.StringValue(script.Name)
 	data.Script = types.StringValue(script.Script)
 	data.CreatedAt = types.StringValue(script.CreatedAt.Format("2006-01-02T15:04:05Z"))
@@ -132,18 +168,34 @@ func (r *StartupScriptResource) Read(ctx context.Context, req resource.ReadReque
 
 func (r *StartupScriptResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data StartupScriptResourceModel
+	var state StartupScriptResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	timeoutsOnly, err := planChangesOnly(req.Plan, req.State, "timeouts")
+	if err != nil {
+		resp.Diagnostics.AddError("Internal Error", fmt.Sprintf("Unable to compare plan with state, got error: %s", err))
+		return
+	}
+
 	// Startup scripts cannot be updated in the Verda API, only deleted and recreated
-	resp.Diagnostics.AddError(
-		"Update Not Supported",
-		"Startup scripts cannot be updated. Please delete and recreate the resource.",
-	)
+	if !timeoutsOnly {
+		resp.Diagnostics.AddError(
+			"Update Not Supported",
+			"Startup scripts cannot be updated. Please delete and recreate the resource.",
+		)
+		return
+	}
+
+	// Only the timeouts changed, which are not sent to the API
+	state.Timeouts = data.Timeouts
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 func (r *StartupScriptResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -155,8 +207,18 @@ func (r *StartupScriptResource) Delete(ctx context.Context, req resource.DeleteR
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	err := r.client.StartupScripts.DeleteStartupScript(ctx, data.ID.ValueString())
-	if err != nil {
+	// Already deleted outside of Terraform
+	if err != nil && !isNotFoundError(err) {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete startup script, got error: %s", err))
 		return
 	}
@@ -3,11 +3,13 @@ package provider
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -16,6 +18,7 @@ import (
 
 var _ resource.Resource = &VolumeResource{}
 var _ resource.ResourceWithImportState = &VolumeResource{}
+var _ resource.ResourceWithModifyPlan = &VolumeResource{}
 
 func NewVolumeResource() resource.Resource {
 	return &VolumeResource{}
@@ -26,14 +29,19 @@ type VolumeResource struct {
 }
 
 type VolumeResourceModel struct {
-	ID         types.String `tfsdk:"id"`
-	Name       types.String `tfsdk:"name"`
-	Size       types.Int64  `tfsdk:"size"`
-	Type       types.String `tfsdk:"type"`
-	Location   types.String `tfsdk:"location"`
-	Status     types.String `tfsdk:"status"`
-	InstanceID types.String `tfsdk:"instance_id"`
-	CreatedAt  types.String `tfsdk:"created_at"`
+	ID                   types.String   `tfsdk:"id"`
+	Name                 types.String   `tfsdk:"name"`
+	Size                 types.Int64    `tfsdk:"size"`
+	Type                 types.String   `tfsdk:"type"`
+	Location             types.String   `tfsdk:"location"`
+	Status               types.String   `tfsdk:"status"`
+	InstanceID           types.String   `tfsdk:"instance_id"`
+	CreatedAt            types.String   `tfsdk:"created_at"`
+	AllowReplaceOnShrink types.Bool     `tfsdk:"allow_replace_on_shrink"`
+	SourceVolumeID       types.String   `tfsdk:"source_volume_id"`
+	PermanentlyDelete    types.Bool     `tfsdk:"permanently_delete"`
+	DeletionProtection   types.Bool     `tfsdk:"deletion_protection"`
+	Timeouts             timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *VolumeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -53,19 +61,29 @@ func (r *VolumeResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				},
 			},
 			"name": schema.StringAttribute{
-				MarkdownDescription: "Name of the volume",
+				MarkdownDescription: "Name of the volume. Can be changed in place.",
 				Required:            true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"size": schema.Int64Attribute{
-				MarkdownDescription: "Size of the volume in GB",
+				MarkdownDescription: "Size of the volume in GB. Increases are applied in place, shrinking requires `allow_replace_on_shrink`.",
 				Required:            true,
-				PlanModifiers: []planmodifier.Int64{
-					int64planmodifier.RequiresReplace(),
+			},
+			"allow_replace_on_shrink": schema.BoolAttribute{
+				MarkdownDescription: "Allow a smaller `size` to replace the volume, which destroys its data. When unset, shrinking is rejected at plan time.",
+				Optional:            true,
+			},
+			"source_volume_id": schema.StringAttribute{
+				MarkdownDescription: "ID of an existing volume to clone. The new volume starts with a copy of its data, and is grown to `size` if that is larger than the source.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"permanently_delete": schema.BoolAttribute{
+				MarkdownDescription: "Delete the volume permanently on destroy instead of moving it to the trash (defaults to false). Trashed volumes can be recovered with `verda_volume_restore`.",
+				Optional:            true,
+			},
+			"deletion_protection": deletionProtectionAttribute("volume"),
 			"type": schema.StringAttribute{
 				MarkdownDescription: "Type of the volume",
 				Required:            true,
@@ -95,6 +113,10 @@ func (r *VolumeResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				MarkdownDescription: "Creation timestamp",
 			},
 		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
 	}
 }
 
@@ -126,6 +148,25 @@ func (r *VolumeResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultVolumeCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	if !data.SourceVolumeID.IsNull() {
+		r.createFromSource(ctx, &data, &resp.Diagnostics)
+
+		// Save the clone even when it failed to become usable, so Terraform taints it instead of leaking it
+		if !data.ID.IsUnknown() {
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		}
+		return
+	}
+
 	createReq := verda.VolumeCreateRequest{
 		Name:         data.Name.ValueString(),
 		Size:         int(data.Size.ValueInt64()),
@@ -145,23 +186,7 @@ func (r *VolumeResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	data.ID = types.StringValue(volume.ID)
-	data.Name = types.StringValue(volume.Name)
-	data.Size = types.Int64Value(int64(volume.Size))
-	data.Type = types.StringValue(volume.Type)
-	data.Status = types.StringValue(volume.Status)
-	data.CreatedAt = types.StringValue(volume.CreatedAt.Format("2006-01-02T15:04:05Z"))
-
-	if volume.InstanceID != nil {
-		data.InstanceID = types.StringValue(*volume.InstanceID)
-	} else {
-		data.InstanceID = types.StringNull()
-	}
-
-	// Set location from response if available
-	if data.Location.IsNull() || data.Location.ValueString() == "" {
-		data.Location = types.StringValue("FIN-01")
-	}
+	flattenVolumeToModel(volume, &data)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -175,40 +200,122 @@ func (r *VolumeResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	volume, err := r.client.Volumes.GetVolume(ctx, data.ID.ValueString())
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	volume, err := r.readVolume(ctx, data.ID.ValueString())
 	if err != nil {
+		if isNotFoundError(err) {
+			// The volume was deleted outside of Terraform, so plan to recreate it
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read volume, got error: %s", err))
 		return
 	}
 
-	data.Name = types.StringValue(volume.Name)
-	data.Size = types.Int64Value(int64(volume.Size))
-	data.Type = types.StringValue(volume.Type)
-	data.Status = types.StringValue(volume.Status)
-	data.CreatedAt = types.StringValue(volume.CreatedAt.Format("2006-01-02T15:04:05Z"))
-
-	if volume.InstanceID != nil {
-		data.InstanceID = types.StringValue(*volume.InstanceID)
-	} else {
-		data.InstanceID = types.StringNull()
+	// Deleted volumes stay readable while they are in the trash
+	if volume.Status == verda.VolumeStatusDeleted {
+		resp.State.RemoveResource(ctx)
+		return
 	}
 
+	flattenVolumeToModel(volume, &data)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *VolumeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data VolumeResourceModel
+	var state VolumeResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultVolumeUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	volumeID := state.ID.ValueString()
+
+	if !data.Name.Equal(state.Name) {
+		err := r.client.Volumes.RenameVolume(ctx, volumeID, verda.VolumeRenameRequest{
+			Name: data.Name.ValueString(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to rename volume, got error: %s", err))
+			return
+		}
+	}
+
+	// Shrinking is either rejected or turned into a replacement in ModifyPlan
+	if data.Size.ValueInt64() > state.Size.ValueInt64() {
+		err := r.client.Volumes.ResizeVolume(ctx, volumeID, verda.VolumeResizeRequest{
+			Size: int(data.Size.ValueInt64()),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to resize volume, got error: %s", err))
+			return
+		}
+	}
+
+	volume, err := r.waitForVolumeUpdate(ctx, volumeID, data.Name.ValueString(), int(data.Size.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read updated volume, got error: %s", err))
+		return
+	}
+
+	flattenVolumeToModel(volume, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// ModifyPlan rejects shrinking a volume, or plans a replacement when allow_replace_on_shrink is set
+func (r *VolumeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the volume is being created or destroyed
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan VolumeResourceModel
+	var state VolumeResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Volumes cannot be updated in the Verda API, only deleted and recreated
-	resp.Diagnostics.AddError(
-		"Update Not Supported",
-		"Volumes cannot be updated. Please delete and recreate the resource.",
+	if plan.Size.IsUnknown() || plan.Size.ValueInt64() >= state.Size.ValueInt64() {
+		return
+	}
+
+	if plan.AllowReplaceOnShrink.ValueBool() {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("size"))
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		path.Root("size"),
+		"Volume Shrink Not Supported",
+		fmt.Sprintf("Volumes can only grow in place. Shrinking from %d GB to %d GB requires replacing the volume, which destroys its data. "+
+			"Set allow_replace_on_shrink = true to accept the replacement.", state.Size.ValueInt64(), plan.Size.ValueInt64()),
 	)
 }
 
@@ -221,13 +328,198 @@ func (r *VolumeResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	err := r.client.Volumes.DeleteVolume(ctx, data.ID.ValueString(), false)
-	if err != nil {
+	if checkDeletionProtection(data.DeletionProtection, "volume", data.Name.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultVolumeDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.client.Volumes.DeleteVolume(ctx, data.ID.ValueString(), data.PermanentlyDelete.ValueBool())
+	// Already deleted outside of Terraform
+	if err != nil && !isNotFoundError(err) {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete volume, got error: %s", err))
 		return
 	}
 }
 
+// readVolume looks the volume up in the volume list, which is shared by all volumes during a
+// refresh. Volumes missing from the list, e.g. because they are in the trash, are read directly.
+func (r *VolumeResource) readVolume(ctx context.Context, volumeID string) (*verda.Volume, error) {
+	volumes, err := r.client.Volumes.ListVolumes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range volumes {
+		if volumes[i].ID == volumeID {
+			return &volumes[i], nil
+		}
+	}
+
+	return r.client.Volumes.GetVolume(ctx, volumeID)
+}
+
+// createFromSource clones the source volume, waits for the clone to become usable and grows it to the configured size
+func (r *VolumeResource) createFromSource(ctx context.Context, data *VolumeResourceModel, diags *diag.Diagnostics) {
+	sourceID := data.SourceVolumeID.ValueString()
+
+	source, err := r.client.Volumes.GetVolume(ctx, sourceID)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read source volume %s, got error: %s", sourceID, err))
+		return
+	}
+
+	// A clone keeps the type and size of its source, so the configuration must be compatible
+	if source.Type != data.Type.ValueString() {
+		diags.AddAttributeError(
+			path.Root("type"),
+			"Invalid Volume Type",
+			fmt.Sprintf("A cloned volume has the type of its source. Source volume %s has type %s, but type is set to %s.", sourceID, source.Type, data.Type.ValueString()),
+		)
+		return
+	}
+
+	if data.Size.ValueInt64() < int64(source.Size) {
+		diags.AddAttributeError(
+			path.Root("size"),
+			"Invalid Volume Size",
+			fmt.Sprintf("A cloned volume cannot be smaller than its source. Source volume %s is %d GB, but size is set to %d GB.", sourceID, source.Size, data.Size.ValueInt64()),
+		)
+		return
+	}
+
+	volumeID, err := r.client.Volumes.CloneVolume(ctx, sourceID, verda.VolumeCloneRequest{
+		Name:         data.Name.ValueString(),
+		LocationCode: data.Location.ValueString(),
+	})
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to clone volume %s, got error: %s", sourceID, err))
+		return
+	}
+
+	data.ID = types.StringValue(volumeID)
+
+	volume, err := r.waitForVolumeReady(ctx, volumeID)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to clone volume %s, got error: %s", sourceID, err))
+		nullUnknownVolumeAttributes(data)
+		return
+	}
+
+	if data.Size.ValueInt64() > int64(volume.Size) {
+		err := r.client.Volumes.ResizeVolume(ctx, volumeID, verda.VolumeResizeRequest{
+			Size: int(data.Size.ValueInt64()),
+		})
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to resize cloned volume, got error: %s", err))
+			flattenVolumeToModel(volume, data)
+			return
+		}
+
+		volume, err = r.waitForVolumeUpdate(ctx, volumeID, "", int(data.Size.ValueInt64()))
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to read resized volume, got error: %s", err))
+			nullUnknownVolumeAttributes(data)
+			return
+		}
+	}
+
+	flattenVolumeToModel(volume, data)
+}
+
+// nullUnknownVolumeAttributes clears the computed attributes that are still unknown, so the
+// model can be saved after a failed create
+func nullUnknownVolumeAttributes(data *VolumeResourceModel) {
+	if data.Status.IsUnknown() {
+		data.Status = types.StringNull()
+	}
+	if data.InstanceID.IsUnknown() {
+		data.InstanceID = types.StringNull()
+	}
+	if data.CreatedAt.IsUnknown() {
+		data.CreatedAt = types.StringNull()
+	}
+	if data.Location.IsUnknown() {
+		data.Location = types.StringNull()
+	}
+}
+
+// waitForVolumeReady polls the volume until it has finished provisioning, e.g. after cloning
+func (r *VolumeResource) waitForVolumeReady(ctx context.Context, volumeID string) (*verda.Volume, error) {
+	for {
+		volume, err := r.client.Volumes.GetVolume(ctx, volumeID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch volume.Status {
+		case verda.VolumeStatusOrdered, verda.VolumeStatusCloning, verda.VolumeStatusRestoring:
+		case verda.VolumeStatusDeleted, verda.VolumeStatusDeleting, verda.VolumeStatusCanceled, verda.VolumeStatusCanceling:
+			return nil, fmt.Errorf("volume has status %s", volume.Status)
+		default:
+			return volume, nil
+		}
+
+		// Wait 5 seconds before trying again, unless the create timeout expires first
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("volume still has status %s: %w", volume.Status, ctx.Err())
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
+// waitForVolumeUpdate polls the volume until it reports the expected name and size, as renaming
+// and resizing are applied asynchronously. An empty name is not checked.
+func (r *VolumeResource) waitForVolumeUpdate(ctx context.Context, volumeID string, name string, size int) (*verda.Volume, error) {
+	for {
+		volume, err := r.client.Volumes.GetVolume(ctx, volumeID)
+		if err != nil {
+			return nil, err
+		}
+
+		if (name == "" || volume.Name == name) && volume.Size >= size {
+			return volume, nil
+		}
+
+		// Wait 5 seconds before trying again, unless the update timeout expires first
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("volume still reports name %q and %d GB instead of %q and %d GB: %w", volume.Name, volume.Size, name, size, ctx.Err())
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
 func (r *VolumeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+func flattenVolumeToModel(volume *verda.Volume, data *VolumeResourceModel) {
+	data.ID = types.StringValue(volume.ID)
+	data.Name = types.StringValue(volume.Name)
+	data.Size = types.Int64Value(int64(volume.Size))
+	data.Type = types.StringValue(volume.Type)
+	data.Status = types.StringValue(volume.Status)
+	data.CreatedAt = types.StringValue(volume.CreatedAt.Format("2006-01-02T15:04:05Z"))
+
+	if volume.InstanceID != nil {
+		data.InstanceID = types.StringValue(*volume.InstanceID)
+	} else {
+		data.InstanceID = types.StringNull()
+	}
+
+	// Set location from response if available, otherwise keep the configured location
+	if volume.Location != "" {
+		data.Location = types.StringValue(volume.Location)
+	} else if data.Location.IsUnknown() {
+		data.Location = types.StringNull()
+	}
+}
@@ -0,0 +1,291 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda"
+)
+
+var _ resource.Resource = &VolumeAttachmentResource{}
+var _ resource.ResourceWithImportState = &VolumeAttachmentResource{}
+
+func NewVolumeAttachmentResource() resource.Resource {
+	return &VolumeAttachmentResource{}
+}
+
+type VolumeAttachmentResource struct {
+	client *verda.Client
+}
+
+type VolumeAttachmentResourceModel struct {
+	ID         types.String   `tfsdk:"id"`
+	VolumeID   types.String   `tfsdk:"volume_id"`
+	InstanceID types.String   `tfsdk:"instance_id"`
+	Status     types.String   `tfsdk:"status"`
+	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *VolumeAttachmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_volume_attachment"
+}
+
+func (r *VolumeAttachmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Attaches an existing Verda volume to an instance",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Attachment identifier (same as the volume ID)",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"volume_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the volume to attach",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"instance_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the instance to attach the volume to. The instance must be shut down while the volume is attached or detached.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Current status of the volume",
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
+	}
+}
+
+func (r *VolumeAttachmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*verda.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *verda.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *VolumeAttachmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data VolumeAttachmentResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultVolumeAttachmentTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	volumeID := data.VolumeID.ValueString()
+	instanceID := data.InstanceID.ValueString()
+
+	err := r.client.Volumes.AttachVolume(ctx, volumeID, verda.VolumeAttachRequest{
+		InstanceID: instanceID,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to attach volume %s to instance %s, got error: %s. "+
+				"Volumes can only be attached to instances that are shut down, e.g. with desired_status = \"shutdown\" on verda_instance.", volumeID, instanceID, err),
+		)
+		return
+	}
+
+	volume, err := r.waitForAttachment(ctx, volumeID, instanceID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to attach volume %s to instance %s, got error: %s", volumeID, instanceID, err))
+		return
+	}
+
+	data.ID = types.StringValue(volume.ID)
+	data.Status = types.StringValue(volume.Status)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *VolumeAttachmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data VolumeAttachmentResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	volume, err := r.client.Volumes.GetVolume(ctx, data.VolumeID.ValueString())
+	if err != nil {
+		if isNotFoundError(err) {
+			// The volume was deleted outside of Terraform, so plan to recreate it
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read volume, got error: %s", err))
+		return
+	}
+
+	// The volume was detached or moved outside of Terraform
+	if volume.InstanceID == nil || (!data.InstanceID.IsNull() && *volume.InstanceID != data.InstanceID.ValueString()) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.ID = types.StringValue(volume.ID)
+	data.InstanceID = types.StringValue(*volume.InstanceID)
+	data.Status = types.StringValue(volume.Status)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *VolumeAttachmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data VolumeAttachmentResourceModel
+	var state VolumeAttachmentResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// volume_id and instance_id require replacement, so only the timeouts can change here
+	state.Timeouts = data.Timeouts
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *VolumeAttachmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data VolumeAttachmentResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultVolumeAttachmentTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	volumeID := data.VolumeID.ValueString()
+	instanceID := data.InstanceID.ValueString()
+
+	err := r.client.Volumes.DetachVolume(ctx, volumeID, verda.VolumeDetachRequest{
+		InstanceID: instanceID,
+	})
+	if isNotFoundError(err) {
+		// The volume was deleted outside of Terraform, so there is nothing to detach
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to detach volume %s from instance %s, got error: %s. "+
+				"Volumes can only be detached from instances that are shut down, e.g. with desired_status = \"shutdown\" on verda_instance.", volumeID, instanceID, err),
+		)
+		return
+	}
+
+	if err := r.waitForDetachment(ctx, volumeID); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to detach volume %s from instance %s, got error: %s", volumeID, instanceID, err))
+		return
+	}
+}
+
+func (r *VolumeAttachmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// The attachment is imported by volume ID, the instance is read from the volume
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("volume_id"), req.ID)...)
+}
+
+// waitForAttachment polls the volume until it is attached to the instance
+func (r *VolumeAttachmentResource) waitForAttachment(ctx context.Context, volumeID string, instanceID string) (*verda.Volume, error) {
+	for {
+		volume, err := r.client.Volumes.GetVolume(ctx, volumeID)
+		if err != nil {
+			return nil, err
+		}
+
+		if volume.InstanceID != nil && *volume.InstanceID == instanceID && volume.Status != verda.VolumeStatusAttaching {
+			return volume, nil
+		}
+
+		// Wait 5 seconds before trying again, unless the create timeout expires first
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("volume still has status %s: %w", volume.Status, ctx.Err())
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
+// waitForDetachment polls the volume until it is no longer attached to any instance
+func (r *VolumeAttachmentResource) waitForDetachment(ctx context.Context, volumeID string) error {
+	for {
+		volume, err := r.client.Volumes.GetVolume(ctx, volumeID)
+		if err != nil {
+			return err
+		}
+
+		if volume.InstanceID == nil && volume.Status != verda.VolumeStatusDetaching {
+			return nil
+		}
+
+		// Wait 5 seconds before trying again, unless the delete timeout expires first
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("volume still has status %s: %w", volume.Status, ctx.Err())
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
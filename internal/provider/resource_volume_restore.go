@@ -0,0 +1,276 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda"
+)
+
+// volumeActionRestore restores a volume from the trash. It is sent like the other volume
+// actions, as PUT /volumes with the volume ID and the action, see the volumes section of the
+// API reference at https://api.verda.com/v1/docs. verdacloud-sdk-go v1.2.1 has no method or
+// VolumeAction constant for it, so the request is built here until the SDK adds one.
+const volumeActionRestore = "restore"
+
+var _ resource.Resource = &VolumeRestoreResource{}
+
+func NewVolumeRestoreResource() resource.Resource {
+	return &VolumeRestoreResource{}
+}
+
+type VolumeRestoreResource struct {
+	client *verda.Client
+}
+
+type VolumeRestoreResourceModel struct {
+	ID       types.String   `tfsdk:"id"`
+	VolumeID types.String   `tfsdk:"volume_id"`
+	Name     types.String   `tfsdk:"name"`
+	Size     types.Int64    `tfsdk:"size"`
+	Type     types.String   `tfsdk:"type"`
+	Location types.String   `tfsdk:"location"`
+	Status   types.String   `tfsdk:"status"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *VolumeRestoreResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_volume_restore"
+}
+
+func (r *VolumeRestoreResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Restores a deleted Verda volume from the trash",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Restore identifier (same as the volume ID)",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"volume_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the deleted volume to restore",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Name of the restored volume",
+			},
+			"size": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Size of the restored volume in GB",
+			},
+			"type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Type of the restored volume",
+			},
+			"location": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Location code of the restored volume",
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Current status of the restored volume",
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
+	}
+}
+
+func (r *VolumeRestoreResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*verda.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *verda.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *VolumeRestoreResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data VolumeRestoreResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultVolumeCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	volumeID := data.VolumeID.ValueString()
+
+	volume, err := r.client.Volumes.GetVolume(ctx, volumeID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read volume %s, got error: %s", volumeID, err))
+		return
+	}
+
+	// Restoring a volume that is not in the trash is a no-op, so the resource can be applied repeatedly
+	if volume.Status == verda.VolumeStatusDeleted {
+		if err := r.restoreVolume(ctx, volumeID); err != nil {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to restore volume %s from the trash, got error: %s. "+
+					"Volumes can only be restored until they are purged from the trash.", volumeID, err),
+			)
+			return
+		}
+
+		volume, err = r.waitForVolumeRestored(ctx, volumeID)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to restore volume %s from the trash, got error: %s", volumeID, err))
+			return
+		}
+	}
+
+	data.ID = types.StringValue(volume.ID)
+	flattenVolumeToRestoreModel(volume, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *VolumeRestoreResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data VolumeRestoreResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	volume, err := r.client.Volumes.GetVolume(ctx, data.VolumeID.ValueString())
+	if err != nil {
+		if isNotFoundError(err) {
+			// The volume was deleted outside of Terraform, so plan to recreate it
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read volume, got error: %s", err))
+		return
+	}
+
+	// The volume was deleted again, so it has to be restored on the next apply
+	if volume.Status == verda.VolumeStatusDeleted {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	flattenVolumeToRestoreModel(volume, &data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *VolumeRestoreResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data VolumeRestoreResourceModel
+	var state VolumeRestoreResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// volume_id requires replacement, so only the timeouts can change here
+	state.Timeouts = data.Timeouts
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *VolumeRestoreResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Destroying the restore only removes it from the state, the restored volume is kept.
+	// Import the volume into a verda_volume resource to manage it.
+}
+
+// restoreVolume moves a volume out of the trash. A rejected restore is reported as a
+// *verda.APIError, the same as for the SDK volume actions.
+func (r *VolumeRestoreResource) restoreVolume(ctx context.Context, volumeID string) error {
+	body, err := json.Marshal(verda.VolumeActionRequest{
+		ID:     volumeID,
+		Action: volumeActionRestore,
+	})
+	if err != nil {
+		return err
+	}
+
+	httpReq, err := r.client.NewRequest(ctx, http.MethodPut, "/volumes", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	_, err = r.client.Do(httpReq, nil)
+	return err
+}
+
+// waitForVolumeRestored polls the volume until it has left the trash
+func (r *VolumeRestoreResource) waitForVolumeRestored(ctx context.Context, volumeID string) (*verda.Volume, error) {
+	for {
+		volume, err := r.client.Volumes.GetVolume(ctx, volumeID)
+		if err != nil {
+			return nil, err
+		}
+
+		if volume.Status != verda.VolumeStatusDeleted && volume.Status != verda.VolumeStatusRestoring {
+			return volume, nil
+		}
+
+		// Wait 5 seconds before trying again, unless the create timeout expires first
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("volume still has status %s: %w", volume.Status, ctx.Err())
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
+func flattenVolumeToRestoreModel(volume *verda.Volume, data *VolumeRestoreResourceModel) {
+	data.Name = types.StringValue(volume.Name)
+	data.Size = types.Int64Value(int64(volume.Size))
+	data.Type = types.StringValue(volume.Type)
+	data.Location = types.StringValue(volume.Location)
+	data.Status = types.StringValue(volume.Status)
+}
@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda"
+)
+
+func TestFlattenVolumeToModelLocation(t *testing.T) {
+	tests := []struct {
+		name        string
+		apiLocation string
+		location    types.String
+		want        types.String
+	}{
+		{name: "reported by the API", apiLocation: "FIN-03", location: types.StringUnknown(), want: types.StringValue("FIN-03")},
+		{name: "reported by the API differs from the state", apiLocation: "FIN-03", location: types.StringValue("FIN-01"), want: types.StringValue("FIN-03")},
+		{name: "not reported, configured", location: types.StringValue("ICE-01"), want: types.StringValue("ICE-01")},
+		{name: "not reported, not configured", location: types.StringUnknown(), want: types.StringNull()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := VolumeResourceModel{Location: tt.location}
+			flattenVolumeToModel(&verda.Volume{ID: "volume-id", Location: tt.apiLocation}, &data)
+
+			if !data.Location.Equal(tt.want) {
+				t.Errorf("location = %s, want %s", data.Location, tt.want)
+			}
+		})
+	}
+}
+
+func TestVolumeUpdateRename(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	NewVolumeResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("Schema() diagnostics = %v", schemaResp.Diagnostics)
+	}
+
+	// volumeState returns the state of a volume with the given name
+	volumeState := func(name string) tfsdk.State {
+		state := tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}
+
+		var diags diag.Diagnostics
+		diags.Append(state.SetAttribute(ctx, path.Root("id"), "volume-id")...)
+		diags.Append(state.SetAttribute(ctx, path.Root("name"), name)...)
+		diags.Append(state.SetAttribute(ctx, path.Root("size"), 100)...)
+		diags.Append(state.SetAttribute(ctx, path.Root("type"), "NVMe")...)
+		diags.Append(state.SetAttribute(ctx, path.Root("location"), "FIN-01")...)
+		diags.Append(state.SetAttribute(ctx, path.Root("status"), "detached")...)
+		diags.Append(state.SetAttribute(ctx, path.Root("created_at"), "2025-01-01T00:00:00Z")...)
+		if diags.HasError() {
+			t.Fatalf("SetAttribute() diagnostics = %v", diags)
+		}
+		return state
+	}
+
+	// The API applies the rename asynchronously, so the first read still reports the old name
+	name := "data"
+	var renamedName string
+	var readsAfterRename int
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/volumes":
+			var actionReq verda.VolumeActionRequest
+			if err := json.NewDecoder(r.Body).Decode(&actionReq); err != nil {
+				t.Errorf("decoding volume action: %v", err)
+			}
+			if actionReq.Action != verda.VolumeActionRename {
+				t.Errorf("action = %s, want %s", actionReq.Action, verda.VolumeActionRename)
+			}
+			renamedName = actionReq.Name
+			w.WriteHeader(http.StatusAccepted)
+		case r.Method == http.MethodGet && r.URL.Path == "/volumes/volume-id":
+			if renamedName != "" {
+				if readsAfterRename > 0 {
+					name = renamedName
+				}
+				readsAfterRename++
+			}
+			_ = json.NewEncoder(w).Encode(verda.Volume{ID: "volume-id", Name: name, Size: 100, Type: "NVMe", Status: "detached", Location: "FIN-01"})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	priorState := volumeState("data")
+	plan := volumeState("data-renamed")
+
+	req := resource.UpdateRequest{
+		Plan:   tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+		Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+		State:  priorState,
+	}
+	resp := resource.UpdateResponse{State: tfsdk.State{Schema: priorState.Schema, Raw: priorState.Raw}}
+
+	r := &VolumeResource{client: client}
+	r.Update(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Update() diagnostics = %v", resp.Diagnostics)
+	}
+
+	var gotName types.String
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("name"), &gotName)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("GetAttribute() diagnostics = %v", resp.Diagnostics)
+	}
+	if gotName.ValueString() != "data-renamed" {
+		t.Errorf("name = %s, want data-renamed", gotName)
+	}
+	if readsAfterRename < 2 {
+		t.Errorf("volume was read %d times after the rename, want it polled until renamed", readsAfterRename)
+	}
+}
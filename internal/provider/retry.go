@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Default retry settings used when the provider configuration and environment do not set them
+const (
+	defaultMaxRetries      = 5
+	defaultRetryMinBackoff = 1 * time.Second
+	defaultRetryMaxBackoff = 30 * time.Second
+)
+
+// retryTransport retries API requests that failed with a transient error. Idempotent requests
+// are retried on 429, 5xx and network errors. Other requests are only retried when the API
+// has certainly not processed them, i.e. on 429 Too Many Requests, or when they are known to
+// be safe to repeat, such as token requests.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+func newRetryTransport(next http.RoundTripper, maxRetries int, minBackoff time.Duration, maxBackoff time.Duration) *retryTransport {
+	return &retryTransport{
+		next:       next,
+		maxRetries: maxRetries,
+		minBackoff: minBackoff,
+		maxBackoff: maxBackoff,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	safe := isSafeToRetry(req)
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 {
+			var err error
+			attemptReq, err = rewindRequest(req)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := t.next.RoundTrip(attemptReq)
+
+		retry := false
+		switch {
+		case err != nil:
+			// The request may have reached the API, so only safe requests are repeated
+			retry = safe && ctx.Err() == nil
+		case resp.StatusCode == http.StatusTooManyRequests:
+			retry = true
+		case resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented:
+			retry = safe
+		}
+
+		// Requests with a body that cannot be replayed cannot be retried
+		if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+			retry = false
+		}
+
+		if !retry || attempt >= t.maxRetries {
+			return resp, err
+		}
+
+		delay := t.backoff(attempt)
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+				delay = retryAfter
+			}
+
+			// Drain the body so the connection can be reused
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns the exponential backoff for the given attempt, with jitter so concurrent
+// requests do not retry in lockstep
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	delay := t.maxBackoff
+	if attempt < 32 {
+		delay = min(t.minBackoff<<attempt, t.maxBackoff)
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	// Wait between half and the full backoff
+	half := delay / 2
+	return half + rand.N(delay-half+1)
+}
+
+// isSafeToRetry reports whether repeating the request cannot create duplicate resources.
+// PUT is not included: the API uses PUT /instances, /volumes and /clusters for actions rather
+// than for replacing a resource. Repeating a volume clone creates a second volume, and
+// repeating an instance start or restart acts on the instance twice.
+func isSafeToRetry(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodDelete:
+		return true
+	}
+
+	// Token requests do not change anything in the account
+	return isTokenRequest(req)
+}
+
+// isTokenRequest reports whether the request fetches an OAuth2 access token
+func isTokenRequest(req *http.Request) bool {
+	return strings.HasSuffix(req.URL.Path, "/oauth2/token")
+}
+
+// rewindRequest returns a copy of the request with a fresh body for another attempt
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	clone := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		clone.Body = body
+	}
+	return clone, nil
+}
+
+// parseRetryAfter parses a Retry-After header in seconds or as an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,242 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		statuses    []int
+		retryAfter  string
+		maxRetries  int
+		minBackoff  time.Duration
+		wantCalls   int
+		wantStatus  int
+		wantNetwork bool
+	}{
+		{name: "GET is retried on server errors", method: http.MethodGet, statuses: []int{503, 502, 200}, wantCalls: 3, wantStatus: 200},
+		{name: "GET is not retried on 501", method: http.MethodGet, statuses: []int{501, 200}, wantCalls: 1, wantStatus: 501},
+		{name: "GET is not retried on client errors", method: http.MethodGet, statuses: []int{404, 200}, wantCalls: 1, wantStatus: 404},
+		{name: "DELETE is retried on server errors", method: http.MethodDelete, statuses: []int{500, 204}, wantCalls: 2, wantStatus: 204},
+		{name: "POST is not retried on server errors", method: http.MethodPost, statuses: []int{503, 201}, wantCalls: 1, wantStatus: 503},
+		{name: "PUT is not retried on server errors", method: http.MethodPut, statuses: []int{500, 200}, wantCalls: 1, wantStatus: 500},
+		{name: "PATCH is not retried on server errors", method: http.MethodPatch, statuses: []int{500, 200}, wantCalls: 1, wantStatus: 500},
+		{name: "POST is retried on 429", method: http.MethodPost, statuses: []int{429, 429, 201}, wantCalls: 3, wantStatus: 201},
+		{name: "token request is retried on server errors", method: http.MethodPost, path: "/oauth2/token", statuses: []int{500, 200}, wantCalls: 2, wantStatus: 200},
+		{name: "retries stop at the maximum", method: http.MethodGet, statuses: []int{503, 503, 503, 503}, maxRetries: 2, wantCalls: 3, wantStatus: 503},
+		{name: "retries are disabled", method: http.MethodGet, statuses: []int{503, 200}, maxRetries: -1, wantCalls: 1, wantStatus: 503},
+		{name: "Retry-After overrides the backoff", method: http.MethodGet, statuses: []int{429, 200}, retryAfter: "0", minBackoff: time.Hour, wantCalls: 2, wantStatus: 200},
+		{name: "GET is retried on network errors", method: http.MethodGet, statuses: []int{0, 200}, wantCalls: 2, wantStatus: 200},
+		{name: "POST is not retried on network errors", method: http.MethodPost, statuses: []int{0, 201}, wantCalls: 1, wantNetwork: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			const body = `{"name":"test"}`
+
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				call := int(calls.Add(1)) - 1
+				status := tt.statuses[min(call, len(tt.statuses)-1)]
+
+				// Every attempt must send the full body again
+				if r.Method != http.MethodGet && r.Method != http.MethodDelete {
+					got, _ := io.ReadAll(r.Body)
+					if string(got) != body {
+						t.Errorf("attempt %d sent body %q, want %q", call+1, got, body)
+					}
+				}
+
+				// Status 0 drops the connection without a response
+				if status == 0 {
+					conn, _, err := w.(http.Hijacker).Hijack()
+					if err != nil {
+						t.Errorf("Hijack() error = %v", err)
+						return
+					}
+					_ = conn.Close()
+					return
+				}
+
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(status)
+			}))
+			defer server.Close()
+
+			maxRetries := tt.maxRetries
+			switch maxRetries {
+			case 0:
+				maxRetries = defaultMaxRetries
+			case -1:
+				maxRetries = 0
+			}
+			minBackoff := tt.minBackoff
+			if minBackoff == 0 {
+				minBackoff = time.Millisecond
+			}
+			transport := newRetryTransport(server.Client().Transport, maxRetries, minBackoff, max(minBackoff, 10*time.Millisecond))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			var reqBody io.Reader
+			if tt.method != http.MethodGet && tt.method != http.MethodDelete {
+				reqBody = strings.NewReader(body)
+			}
+			req, err := http.NewRequestWithContext(ctx, tt.method, server.URL+tt.path, reqBody)
+			if err != nil {
+				t.Fatalf("NewRequest() error = %v", err)
+			}
+
+			resp, err := transport.RoundTrip(req)
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+
+			if got := int(calls.Load()); got != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", got, tt.wantCalls)
+			}
+			if tt.wantNetwork {
+				if err == nil {
+					t.Errorf("RoundTrip() error = nil, want a network error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RoundTrip() error = %v", err)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestRetryTransportContextCanceled(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	transport := newRetryTransport(server.Client().Transport, defaultMaxRetries, time.Hour, time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("RoundTrip() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("calls = %d, want 1", got)
+	}
+}
+
+func TestRetryTransportBackoff(t *testing.T) {
+	tests := []struct {
+		name       string
+		minBackoff time.Duration
+		maxBackoff time.Duration
+		attempt    int
+		want       time.Duration
+	}{
+		{name: "first attempt", minBackoff: time.Second, maxBackoff: 30 * time.Second, attempt: 0, want: time.Second},
+		{name: "doubles per attempt", minBackoff: time.Second, maxBackoff: 30 * time.Second, attempt: 3, want: 8 * time.Second},
+		{name: "capped at the maximum", minBackoff: time.Second, maxBackoff: 30 * time.Second, attempt: 5, want: 30 * time.Second},
+		{name: "no overflow on many attempts", minBackoff: time.Second, maxBackoff: 30 * time.Second, attempt: 100, want: 30 * time.Second},
+		{name: "zero backoff", minBackoff: 0, maxBackoff: 0, attempt: 2, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := newRetryTransport(http.DefaultTransport, defaultMaxRetries, tt.minBackoff, tt.maxBackoff)
+
+			// The jitter waits between half and the full backoff
+			for range 100 {
+				got := transport.backoff(tt.attempt)
+				if got < tt.want/2 || got > tt.want {
+					t.Fatalf("backoff(%d) = %s, want between %s and %s", tt.attempt, got, tt.want/2, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Duration
+		wantMin time.Duration
+		wantOK  bool
+	}{
+		{name: "empty", value: "", wantOK: false},
+		{name: "seconds", value: "120", want: 2 * time.Minute, wantMin: 2 * time.Minute, wantOK: true},
+		{name: "zero seconds", value: "0", want: 0, wantOK: true},
+		{name: "negative seconds", value: "-5", wantOK: false},
+		{name: "invalid", value: "soon", wantOK: false},
+		{name: "date in the future", value: time.Now().Add(time.Minute).UTC().Format(http.TimeFormat), want: time.Minute, wantMin: 55 * time.Second, wantOK: true},
+		{name: "date in the past", value: time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), want: 0, wantOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.value)
+			if ok != tt.wantOK {
+				t.Fatalf("parseRetryAfter(%q) ok = %v, want %v", tt.value, ok, tt.wantOK)
+			}
+			if got < tt.wantMin || got > tt.want {
+				t.Errorf("parseRetryAfter(%q) = %s, want between %s and %s", tt.value, got, tt.wantMin, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsSafeToRetry(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		want   bool
+	}{
+		{method: http.MethodGet, path: "/v1/instances", want: true},
+		{method: http.MethodHead, path: "/v1/instances", want: true},
+		{method: http.MethodOptions, path: "/v1/instances", want: true},
+		{method: http.MethodDelete, path: "/v1/ssh-keys/key-id", want: true},
+		{method: http.MethodPost, path: "/v1/instances", want: false},
+		{method: http.MethodPut, path: "/v1/volumes", want: false},
+		{method: http.MethodPatch, path: "/v1/job-deployments/job", want: false},
+		{method: http.MethodPost, path: "/v1/oauth2/token", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if got := isSafeToRetry(req); got != tt.want {
+				t.Errorf("isSafeToRetry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda"
+)
+
+// envVarAttrTypes are the attribute types of an env entry
+var envVarAttrTypes = map[string]attr.Type{
+	"type":                         types.StringType,
+	"name":                         types.StringType,
+	"value_or_reference_to_secret": types.StringType,
+}
+
+// sensitiveEnvVarAttrTypes are the attribute types of a sensitive_env entry
+var sensitiveEnvVarAttrTypes = map[string]attr.Type{
+	"type":                         types.StringType,
+	"name":                         types.StringType,
+	"value_or_reference_to_secret": types.StringType,
+	"value_wo":                     types.StringType,
+}
+
+// SensitiveEnvVarModel is a sensitive_env entry. ValueWO is write-only, so it is only set
+// when read from the configuration.
+type SensitiveEnvVarModel struct {
+	Type                     types.String `tfsdk:"type"`
+	Name                     types.String `tfsdk:"name"`
+	ValueOrReferenceToSecret types.String `tfsdk:"value_or_reference_to_secret"`
+	ValueWO                  types.String `tfsdk:"value_wo"`
+}
+
+// configWriteOnlyEnv returns the write-only sensitive_env values from the configuration, keyed
+// by container index and variable name. Write-only values are never part of the plan.
+func configWriteOnlyEnv(ctx context.Context, config tfsdk.Config, diagnostics *diag.Diagnostics) map[int]map[string]string {
+	var containers []ContainerModel
+	diagnostics.Append(config.GetAttribute(ctx, path.Root("containers"), &containers)...)
+	if diagnostics.HasError() {
+		return nil
+	}
+
+	writeOnlyEnv := map[int]map[string]string{}
+	for i, container := range containers {
+		if container.SensitiveEnv.IsNull() || container.SensitiveEnv.IsUnknown() {
+			continue
+		}
+
+		var sensitiveEnvVars []SensitiveEnvVarModel
+		diagnostics.Append(container.SensitiveEnv.ElementsAs(ctx, &sensitiveEnvVars, false)...)
+		if diagnostics.HasError() {
+			return nil
+		}
+
+		for _, envVar := range sensitiveEnvVars {
+			if envVar.ValueWO.IsNull() {
+				continue
+			}
+			if writeOnlyEnv[i] == nil {
+				writeOnlyEnv[i] = map[string]string{}
+			}
+			writeOnlyEnv[i][envVar.Name.ValueString()] = envVar.ValueWO.ValueString()
+		}
+	}
+
+	return writeOnlyEnv
+}
+
+// expandSensitiveEnv returns the sensitive environment variables of a container for the API
+// request, taking write-only values from writeOnlyValues. A variable may not be set in both
+// env and sensitive_env.
+func expandSensitiveEnv(ctx context.Context, container ContainerModel, writeOnlyValues map[string]string, diagnostics *diag.Diagnostics) []verda.ContainerEnvVar {
+	if container.SensitiveEnv.IsNull() || container.SensitiveEnv.IsUnknown() {
+		return nil
+	}
+
+	var envVars []EnvVarModel
+	var sensitiveEnvVars []SensitiveEnvVarModel
+	if !container.Env.IsNull() && !container.Env.IsUnknown() {
+		diagnostics.Append(container.Env.ElementsAs(ctx, &envVars, false)...)
+	}
+	diagnostics.Append(container.SensitiveEnv.ElementsAs(ctx, &sensitiveEnvVars, false)...)
+	if diagnostics.HasError() {
+		return nil
+	}
+
+	var containerEnvVars []verda.ContainerEnvVar
+	for _, envVar := range sensitiveEnvVars {
+		name := envVar.Name.ValueString()
+
+		if slices.ContainsFunc(envVars, func(other EnvVarModel) bool { return other.Name.ValueString() == name }) {
+			diagnostics.AddError(
+				"Invalid Environment Variables",
+				fmt.Sprintf("Environment variable %s is set in both env and sensitive_env of container %s. Set it in only one of them.", name, container.Image.ValueString()),
+			)
+			return nil
+		}
+
+		value := envVar.ValueOrReferenceToSecret.ValueString()
+		if envVar.ValueOrReferenceToSecret.IsNull() {
+			value = writeOnlyValues[name]
+		}
+
+		containerEnvVars = append(containerEnvVars, verda.ContainerEnvVar{
+			Type:                     envVar.Type.ValueString(),
+			Name:                     name,
+			ValueOrReferenceToSecret: value,
+		})
+	}
+
+	return containerEnvVars
+}
+
+// separateSensitiveEnv splits the environment variables returned by the API into env and
+// sensitive_env. The API returns all values in plain text, so variables that are configured
+// in sensitive_env are removed from env, and their values are taken from the plan or prior
+// state instead. Sensitive variables that were removed outside of Terraform are dropped, so
+// they show up as a change.
+func separateSensitiveEnv(ctx context.Context, apiEnv types.List, sensitiveEnv types.List, diagnostics *diag.Diagnostics) (types.List, types.List) {
+	if sensitiveEnv.IsNull() || sensitiveEnv.IsUnknown() {
+		return apiEnv, sensitiveEnv
+	}
+
+	var apiEnvVars []EnvVarModel
+	var sensitiveEnvVars []SensitiveEnvVarModel
+	if !apiEnv.IsNull() && !apiEnv.IsUnknown() {
+		diagnostics.Append(apiEnv.ElementsAs(ctx, &apiEnvVars, false)...)
+	}
+	diagnostics.Append(sensitiveEnv.ElementsAs(ctx, &sensitiveEnvVars, false)...)
+	if diagnostics.HasError() {
+		return apiEnv, sensitiveEnv
+	}
+
+	isSensitive := func(envVar EnvVarModel) bool {
+		return slices.ContainsFunc(sensitiveEnvVars, func(sensitive SensitiveEnvVarModel) bool {
+			return sensitive.Name.Equal(envVar.Name)
+		})
+	}
+	existsInAPI := func(sensitive SensitiveEnvVarModel) bool {
+		return slices.ContainsFunc(apiEnvVars, func(envVar EnvVarModel) bool {
+			return envVar.Name.Equal(sensitive.Name)
+		})
+	}
+
+	envVars := slices.DeleteFunc(slices.Clone(apiEnvVars), isSensitive)
+	sensitiveEnvVars = slices.DeleteFunc(sensitiveEnvVars, func(sensitive SensitiveEnvVarModel) bool { return !existsInAPI(sensitive) })
+
+	return envVarList(ctx, envVars, envVarAttrTypes, diagnostics), envVarList(ctx, sensitiveEnvVars, sensitiveEnvVarAttrTypes, diagnostics)
+}
+
+// envVarList converts environment variables to a list value, or a null list if there are none
+func envVarList[T any](ctx context.Context, envVars []T, attrTypes map[string]attr.Type, diagnostics *diag.Diagnostics) types.List {
+	elementType := types.ObjectType{AttrTypes: attrTypes}
+	if len(envVars) == 0 {
+		return types.ListNull(elementType)
+	}
+
+	list, diags := types.ListValueFrom(ctx, elementType, envVars)
+	diagnostics.Append(diags...)
+	return list
+}
@@ -0,0 +1,292 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/verda-cloud/verdacloud-sdk-go/pkg/verda"
+)
+
+// testEnvList returns an env list with plain values, given as name and value pairs
+func testEnvList(t *testing.T, pairs ...string) types.List {
+	t.Helper()
+
+	var envVars []EnvVarModel
+	for i := 0; i < len(pairs); i += 2 {
+		envVars = append(envVars, EnvVarModel{
+			Type:                     types.StringValue("plain"),
+			Name:                     types.StringValue(pairs[i]),
+			ValueOrReferenceToSecret: types.StringValue(pairs[i+1]),
+		})
+	}
+
+	var diags diag.Diagnostics
+	list := envVarList(context.Background(), envVars, envVarAttrTypes, &diags)
+	if diags.HasError() {
+		t.Fatalf("envVarList() diagnostics = %v", diags)
+	}
+	return list
+}
+
+// testSensitiveEnvList returns a sensitive_env list as it is stored in the plan or state,
+// where value_wo is always null
+func testSensitiveEnvList(t *testing.T, envVars ...SensitiveEnvVarModel) types.List {
+	t.Helper()
+
+	for i := range envVars {
+		if envVars[i].Type.IsNull() {
+			envVars[i].Type = types.StringValue("plain")
+		}
+		envVars[i].ValueWO = types.StringNull()
+	}
+
+	var diags diag.Diagnostics
+	list := envVarList(context.Background(), envVars, sensitiveEnvVarAttrTypes, &diags)
+	if diags.HasError() {
+		t.Fatalf("envVarList() diagnostics = %v", diags)
+	}
+	return list
+}
+
+// sensitiveEnvVar returns a sensitive_env entry. A null value stands for a value set through value_wo.
+func sensitiveEnvVar(name string, value types.String) SensitiveEnvVarModel {
+	return SensitiveEnvVarModel{
+		Type:                     types.StringNull(),
+		Name:                     types.StringValue(name),
+		ValueOrReferenceToSecret: value,
+	}
+}
+
+func TestExpandSensitiveEnv(t *testing.T) {
+	tests := []struct {
+		name            string
+		env             []string
+		sensitiveEnv    []SensitiveEnvVarModel
+		writeOnlyValues map[string]string
+		want            []verda.ContainerEnvVar
+		wantErr         bool
+	}{
+		{
+			name: "no sensitive env",
+			env:  []string{"LOG_LEVEL", "info"},
+			want: nil,
+		},
+		{
+			name:         "value in state",
+			sensitiveEnv: []SensitiveEnvVarModel{sensitiveEnvVar("API_KEY", types.StringValue("secret"))},
+			want:         []verda.ContainerEnvVar{{Type: "plain", Name: "API_KEY", ValueOrReferenceToSecret: "secret"}},
+		},
+		{
+			name:            "write-only value",
+			sensitiveEnv:    []SensitiveEnvVarModel{sensitiveEnvVar("API_KEY", types.StringNull())},
+			writeOnlyValues: map[string]string{"API_KEY": "write-only"},
+			want:            []verda.ContainerEnvVar{{Type: "plain", Name: "API_KEY", ValueOrReferenceToSecret: "write-only"}},
+		},
+		{
+			name: "write-only values are matched by name",
+			sensitiveEnv: []SensitiveEnvVarModel{
+				sensitiveEnvVar("API_KEY", types.StringValue("secret")),
+				sensitiveEnvVar("DB_PASSWORD", types.StringNull()),
+			},
+			writeOnlyValues: map[string]string{"API_KEY": "ignored", "DB_PASSWORD": "write-only"},
+			want: []verda.ContainerEnvVar{
+				{Type: "plain", Name: "API_KEY", ValueOrReferenceToSecret: "secret"},
+				{Type: "plain", Name: "DB_PASSWORD", ValueOrReferenceToSecret: "write-only"},
+			},
+		},
+		{
+			name:         "alongside env",
+			env:          []string{"LOG_LEVEL", "info"},
+			sensitiveEnv: []SensitiveEnvVarModel{sensitiveEnvVar("API_KEY", types.StringValue("secret"))},
+			want:         []verda.ContainerEnvVar{{Type: "plain", Name: "API_KEY", ValueOrReferenceToSecret: "secret"}},
+		},
+		{
+			name:         "set in both env and sensitive_env",
+			env:          []string{"API_KEY", "plain"},
+			sensitiveEnv: []SensitiveEnvVarModel{sensitiveEnvVar("API_KEY", types.StringValue("secret"))},
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			container := ContainerModel{
+				Image:        types.StringValue("registry.example.com/app:1.0"),
+				Env:          testEnvList(t, tt.env...),
+				SensitiveEnv: testSensitiveEnvList(t, tt.sensitiveEnv...),
+			}
+
+			var diags diag.Diagnostics
+			got := expandSensitiveEnv(context.Background(), container, tt.writeOnlyValues, &diags)
+
+			if diags.HasError() != tt.wantErr {
+				t.Fatalf("expandSensitiveEnv() diagnostics = %v, wantErr %v", diags, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expandSensitiveEnv() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeparateSensitiveEnv(t *testing.T) {
+	tests := []struct {
+		name             string
+		apiEnv           []string
+		sensitiveEnv     []SensitiveEnvVarModel
+		wantEnv          []string
+		wantSensitiveEnv []SensitiveEnvVarModel
+	}{
+		{
+			name:    "no sensitive env",
+			apiEnv:  []string{"LOG_LEVEL", "info", "API_KEY", "secret"},
+			wantEnv: []string{"LOG_LEVEL", "info", "API_KEY", "secret"},
+		},
+		{
+			name:             "sensitive variables are removed from env",
+			apiEnv:           []string{"LOG_LEVEL", "info", "API_KEY", "secret"},
+			sensitiveEnv:     []SensitiveEnvVarModel{sensitiveEnvVar("API_KEY", types.StringValue("secret"))},
+			wantEnv:          []string{"LOG_LEVEL", "info"},
+			wantSensitiveEnv: []SensitiveEnvVarModel{sensitiveEnvVar("API_KEY", types.StringValue("secret"))},
+		},
+		{
+			name:             "sensitive values are kept from the state",
+			apiEnv:           []string{"API_KEY", "changed-outside"},
+			sensitiveEnv:     []SensitiveEnvVarModel{sensitiveEnvVar("API_KEY", types.StringValue("secret"))},
+			wantSensitiveEnv: []SensitiveEnvVarModel{sensitiveEnvVar("API_KEY", types.StringValue("secret"))},
+		},
+		{
+			name:             "write-only values stay out of the state",
+			apiEnv:           []string{"LOG_LEVEL", "info", "API_KEY", "write-only"},
+			sensitiveEnv:     []SensitiveEnvVarModel{sensitiveEnvVar("API_KEY", types.StringNull())},
+			wantEnv:          []string{"LOG_LEVEL", "info"},
+			wantSensitiveEnv: []SensitiveEnvVarModel{sensitiveEnvVar("API_KEY", types.StringNull())},
+		},
+		{
+			name:   "variables removed outside of Terraform are dropped",
+			apiEnv: []string{"LOG_LEVEL", "info", "API_KEY", "secret"},
+			sensitiveEnv: []SensitiveEnvVarModel{
+				sensitiveEnvVar("API_KEY", types.StringValue("secret")),
+				sensitiveEnvVar("DB_PASSWORD", types.StringValue("password")),
+			},
+			wantEnv:          []string{"LOG_LEVEL", "info"},
+			wantSensitiveEnv: []SensitiveEnvVarModel{sensitiveEnvVar("API_KEY", types.StringValue("secret"))},
+		},
+		{
+			name:         "no env returned by the API",
+			sensitiveEnv: []SensitiveEnvVarModel{sensitiveEnvVar("API_KEY", types.StringValue("secret"))},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			gotEnv, gotSensitiveEnv := separateSensitiveEnv(context.Background(), testEnvList(t, tt.apiEnv...), testSensitiveEnvList(t, tt.sensitiveEnv...), &diags)
+			if diags.HasError() {
+				t.Fatalf("separateSensitiveEnv() diagnostics = %v", diags)
+			}
+
+			if wantEnv := testEnvList(t, tt.wantEnv...); !gotEnv.Equal(wantEnv) {
+				t.Errorf("env = %s, want %s", gotEnv, wantEnv)
+			}
+			if wantSensitiveEnv := testSensitiveEnvList(t, tt.wantSensitiveEnv...); !gotSensitiveEnv.Equal(wantSensitiveEnv) {
+				t.Errorf("sensitive_env = %s, want %s", gotSensitiveEnv, wantSensitiveEnv)
+			}
+		})
+	}
+}
+
+func TestConfigWriteOnlyEnv(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	NewContainerResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("Schema() diagnostics = %v", schemaResp.Diagnostics)
+	}
+
+	type sensitiveEnvConfig struct {
+		name    string
+		value   string
+		valueWO string
+	}
+
+	tests := []struct {
+		name       string
+		containers [][]sensitiveEnvConfig
+		want       map[int]map[string]string
+	}{
+		{
+			name: "no containers",
+			want: map[int]map[string]string{},
+		},
+		{
+			name:       "no sensitive env",
+			containers: [][]sensitiveEnvConfig{nil},
+			want:       map[int]map[string]string{},
+		},
+		{
+			name: "write-only values by container",
+			containers: [][]sensitiveEnvConfig{
+				{{name: "API_KEY", valueWO: "key"}, {name: "LOG_TOKEN", value: "in-state"}},
+				nil,
+				{{name: "DB_PASSWORD", valueWO: "password"}},
+			},
+			want: map[int]map[string]string{
+				0: {"API_KEY": "key"},
+				2: {"DB_PASSWORD": "password"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Build the configuration through a state, which can set values by path
+			state := tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}
+
+			var diags diag.Diagnostics
+			for i, sensitiveEnv := range tt.containers {
+				containerPath := path.Root("containers").AtListIndex(i)
+				diags.Append(state.SetAttribute(ctx, containerPath.AtName("image"), "registry.example.com/app:1.0")...)
+
+				for j, envVar := range sensitiveEnv {
+					envVarPath := containerPath.AtName("sensitive_env").AtListIndex(j)
+					value, valueWO := types.StringNull(), types.StringNull()
+					if envVar.value != "" {
+						value = types.StringValue(envVar.value)
+					}
+					if envVar.valueWO != "" {
+						valueWO = types.StringValue(envVar.valueWO)
+					}
+
+					diags.Append(state.SetAttribute(ctx, envVarPath.AtName("type"), "plain")...)
+					diags.Append(state.SetAttribute(ctx, envVarPath.AtName("name"), envVar.name)...)
+					diags.Append(state.SetAttribute(ctx, envVarPath.AtName("value_or_reference_to_secret"), value)...)
+					diags.Append(state.SetAttribute(ctx, envVarPath.AtName("value_wo"), valueWO)...)
+				}
+			}
+			if diags.HasError() {
+				t.Fatalf("SetAttribute() diagnostics = %v", diags)
+			}
+
+			config := tfsdk.Config{Schema: state.Schema, Raw: state.Raw}
+			got := configWriteOnlyEnv(ctx, config, &diags)
+			if diags.HasError() {
+				t.Fatalf("configWriteOnlyEnv() diagnostics = %v", diags)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("configWriteOnlyEnv() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
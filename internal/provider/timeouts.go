@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Default timeouts used when the resource has no timeouts block
+const (
+	defaultTimeout = 5 * time.Minute
+
+	defaultInstanceCreateTimeout = 30 * time.Minute
+	defaultInstanceUpdateTimeout = 20 * time.Minute
+	defaultInstanceDeleteTimeout = 20 * time.Minute
+
+	defaultVolumeCreateTimeout = 10 * time.Minute
+	defaultVolumeUpdateTimeout = 10 * time.Minute
+	defaultVolumeDeleteTimeout = 10 * time.Minute
+
+	defaultVolumeAttachmentTimeout = 10 * time.Minute
+
+	defaultDeploymentCreateTimeout = 20 * time.Minute
+	defaultDeploymentUpdateTimeout = 20 * time.Minute
+	defaultDeploymentDeleteTimeout = 10 * time.Minute
+)
+
+// deadlineMilliseconds returns the time left until the context deadline in milliseconds,
+// capped at maxMilliseconds. It is used for API calls that take a server-side timeout.
+func deadlineMilliseconds(ctx context.Context, maxMilliseconds int) int {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return maxMilliseconds
+	}
+
+	remaining := int(time.Until(deadline).Milliseconds())
+	if remaining < 0 {
+		return 0
+	}
+
+	return min(remaining, maxMilliseconds)
+}
+
+// planChangesOnly reports whether the plan differs from the state only in the given
+// top-level attributes or blocks. Unknown plan values are computed by the provider and
+// are not treated as changes.
+func planChangesOnly(plan tfsdk.Plan, state tfsdk.State, attributes ...string) (bool, error) {
+	var planValues, stateValues map[string]tftypes.Value
+
+	if err := plan.Raw.As(&planValues); err != nil {
+		return false, err
+	}
+
+	if err := state.Raw.As(&stateValues); err != nil {
+		return false, err
+	}
+
+	for name, planValue := range planValues {
+		if slices.Contains(attributes, name) || !planValue.IsKnown() {
+			continue
+		}
+
+		if !planValue.Equal(stateValues[name]) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
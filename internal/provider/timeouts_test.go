@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestPlanChangesOnly(t *testing.T) {
+	testSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":                  schema.StringAttribute{Computed: true},
+			"name":                schema.StringAttribute{Required: true},
+			"size":                schema.Int64Attribute{Required: true},
+			"deletion_protection": schema.BoolAttribute{Optional: true},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"update": schema.StringAttribute{Optional: true},
+				},
+			},
+		},
+	}
+
+	timeoutsType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"update": tftypes.String}}
+	objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"id":                  tftypes.String,
+		"name":                tftypes.String,
+		"size":                tftypes.Number,
+		"deletion_protection": tftypes.Bool,
+		"timeouts":            timeoutsType,
+	}}
+
+	// value returns the object with the given attributes replaced
+	value := func(changes map[string]tftypes.Value) tftypes.Value {
+		values := map[string]tftypes.Value{
+			"id":                  tftypes.NewValue(tftypes.String, "id-1"),
+			"name":                tftypes.NewValue(tftypes.String, "volume"),
+			"size":                tftypes.NewValue(tftypes.Number, 100),
+			"deletion_protection": tftypes.NewValue(tftypes.Bool, nil),
+			"timeouts":            tftypes.NewValue(timeoutsType, nil),
+		}
+		for name, v := range changes {
+			values[name] = v
+		}
+		return tftypes.NewValue(objectType, values)
+	}
+
+	tests := []struct {
+		name       string
+		plan       map[string]tftypes.Value
+		attributes []string
+		want       bool
+	}{
+		{
+			name:       "no changes",
+			attributes: []string{"timeouts"},
+			want:       true,
+		},
+		{
+			name: "only timeouts changed",
+			plan: map[string]tftypes.Value{
+				"timeouts": tftypes.NewValue(timeoutsType, map[string]tftypes.Value{"update": tftypes.NewValue(tftypes.String, "30m")}),
+			},
+			attributes: []string{"timeouts"},
+			want:       true,
+		},
+		{
+			name: "only listed attributes changed",
+			plan: map[string]tftypes.Value{
+				"timeouts":            tftypes.NewValue(timeoutsType, map[string]tftypes.Value{"update": tftypes.NewValue(tftypes.String, "30m")}),
+				"deletion_protection": tftypes.NewValue(tftypes.Bool, true),
+			},
+			attributes: []string{"timeouts", "deletion_protection"},
+			want:       true,
+		},
+		{
+			name: "other attribute changed",
+			plan: map[string]tftypes.Value{
+				"timeouts": tftypes.NewValue(timeoutsType, map[string]tftypes.Value{"update": tftypes.NewValue(tftypes.String, "30m")}),
+				"size":     tftypes.NewValue(tftypes.Number, 200),
+			},
+			attributes: []string{"timeouts"},
+			want:       false,
+		},
+		{
+			name: "attribute set from null",
+			plan: map[string]tftypes.Value{
+				"deletion_protection": tftypes.NewValue(tftypes.Bool, false),
+			},
+			attributes: []string{"timeouts"},
+			want:       false,
+		},
+		{
+			name: "unknown computed attribute",
+			plan: map[string]tftypes.Value{
+				"id": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			},
+			attributes: []string{"timeouts"},
+			want:       true,
+		},
+		{
+			name: "no attributes allowed",
+			plan: map[string]tftypes.Value{
+				"timeouts": tftypes.NewValue(timeoutsType, map[string]tftypes.Value{"update": tftypes.NewValue(tftypes.String, "30m")}),
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := tfsdk.Plan{Schema: testSchema, Raw: value(tt.plan)}
+			state := tfsdk.State{Schema: testSchema, Raw: value(nil)}
+
+			got, err := planChangesOnly(plan, state, tt.attributes...)
+			if err != nil {
+				t.Fatalf("planChangesOnly() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("planChangesOnly() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,8 +2,14 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
@@ -137,3 +143,225 @@ func (v volumeMountValidator) ValidateObject(ctx context.Context, req validator.
 		)
 	}
 }
+
+// stringOneOfValidator validates that a string attribute is one of the allowed values
+type stringOneOfValidator struct {
+	values []string
+}
+
+func (v stringOneOfValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("Value must be one of: %s", strings.Join(v.values, ", "))
+}
+
+func (v stringOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringOneOfValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for _, value := range v.values {
+		if req.ConfigValue.ValueString() == value {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Attribute Value",
+		fmt.Sprintf("Value must be one of: %s, got: '%s'", strings.Join(v.values, ", "), req.ConfigValue.ValueString()),
+	)
+}
+
+// durationValidator validates that a string attribute is a valid Go duration (e.g., "30m")
+type durationValidator struct{}
+
+func (v durationValidator) Description(ctx context.Context) string {
+	return "Value must be a valid duration (e.g., '30s', '10m', '1h')"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	duration, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil || duration < 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("Value must be a valid non-negative duration (e.g., '30s', '10m', '1h'), got: '%s'", req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// jsonStringValidator validates that a string attribute is well-formed JSON
+type jsonStringValidator struct{}
+
+func (v jsonStringValidator) Description(ctx context.Context) string {
+	return "Value must be valid JSON"
+}
+
+func (v jsonStringValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v jsonStringValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	// The value is usually a secret, so the parse error, which may quote it, is not shown
+	if !json.Valid([]byte(req.ConfigValue.ValueString())) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid JSON",
+			"Value must be valid JSON",
+		)
+	}
+}
+
+// registryCredentialsTypes lists, per registry type, the combinations of fields that are
+// accepted. One combination must be fully set, and no fields outside all combinations may be set.
+var registryCredentialsTypes = map[string][][]string{
+	"dockerhub": {{"username", "access_token"}, {"docker_config_json"}},
+	"ghcr":      {{"username", "access_token"}},
+	"gcr":       {{"service_account_key"}},
+	"ecr":       {{"access_key_id", "secret_access_key", "region", "ecr_repo"}},
+	"scaleway":  {{"access_token", "scaleway_domain", "scaleway_uuid"}},
+	"custom":    {{"docker_config_json"}},
+}
+
+// registryCredentialsFields are all credential fields, each of which may be set through
+// the attribute itself or, for secrets, through its write-only variant
+var registryCredentialsFields = []string{
+	"username",
+	"access_token",
+	"service_account_key",
+	"docker_config_json",
+	"access_key_id",
+	"secret_access_key",
+	"region",
+	"ecr_repo",
+	"scaleway_domain",
+	"scaleway_uuid",
+}
+
+// registryCredentialsValidator validates that registry credentials set the fields required by their type
+type registryCredentialsValidator struct{}
+
+var _ resource.ConfigValidator = registryCredentialsValidator{}
+
+func (v registryCredentialsValidator) Description(ctx context.Context) string {
+	return "Validates that the credential fields match the registry type"
+}
+
+func (v registryCredentialsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v registryCredentialsValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var registryType types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("type"), &registryType)...)
+	if resp.Diagnostics.HasError() || registryType.IsNull() || registryType.IsUnknown() {
+		return
+	}
+
+	// Unknown types are reported by the type attribute validator
+	combinations, ok := registryCredentialsTypes[registryType.ValueString()]
+	if !ok {
+		return
+	}
+
+	// Unknown values are treated as set, as they will usually be known at apply time
+	set := map[string]bool{}
+	for _, field := range registryCredentialsFields {
+		names := []string{field}
+		if _, ok := writeOnlyCredentialAttributes[field+"_wo"]; ok {
+			names = append(names, field+"_wo")
+		}
+
+		for _, name := range names {
+			var value types.String
+			resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name), &value)...)
+			if !value.IsNull() {
+				set[field] = true
+			}
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, field := range registryCredentialsFields {
+		if set[field] && !slices.ContainsFunc(combinations, func(combination []string) bool { return slices.Contains(combination, field) }) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(field),
+				"Invalid Field for Registry Type",
+				fmt.Sprintf("%s cannot be set for '%s' registry credentials", field, registryType.ValueString()),
+			)
+		}
+	}
+
+	satisfied := slices.ContainsFunc(combinations, func(combination []string) bool {
+		return !slices.ContainsFunc(combination, func(field string) bool { return !set[field] })
+	})
+	if satisfied {
+		return
+	}
+
+	var accepted []string
+	for _, combination := range combinations {
+		accepted = append(accepted, strings.Join(combination, ", "))
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		path.Root("type"),
+		"Missing Required Fields",
+		fmt.Sprintf("'%s' registry credentials require: %s", registryType.ValueString(), strings.Join(accepted, "; or ")),
+	)
+}
+
+// sensitiveEnvValueValidator validates that a sensitive_env entry sets exactly one of
+// value_or_reference_to_secret and value_wo
+type sensitiveEnvValueValidator struct{}
+
+func (v sensitiveEnvValueValidator) Description(ctx context.Context) string {
+	return "Exactly one of value_or_reference_to_secret and value_wo must be set"
+}
+
+func (v sensitiveEnvValueValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v sensitiveEnvValueValidator) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	attrs := req.ConfigValue.Attributes()
+	value, _ := attrs["value_or_reference_to_secret"].(types.String)
+	valueWO, _ := attrs["value_wo"].(types.String)
+
+	if !value.IsNull() && !valueWO.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			req.Path.AtName("value_wo"),
+			"Conflicting Attributes",
+			"Only one of value_or_reference_to_secret and value_wo can be set. Use value_wo to keep the value out of the state.",
+		)
+	}
+
+	if value.IsNull() && valueWO.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			req.Path.AtName("value_or_reference_to_secret"),
+			"Missing Required Field",
+			"One of value_or_reference_to_secret or value_wo must be set",
+		)
+	}
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestRegistryCredentialsValidator(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	NewContainerRegistryCredentialsResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("Schema() diagnostics = %v", schemaResp.Diagnostics)
+	}
+
+	unknown := types.StringUnknown()
+
+	tests := []struct {
+		name       string
+		config     map[string]any
+		wantErrors []string
+	}{
+		{
+			name:   "dockerhub with username and access token",
+			config: map[string]any{"type": "dockerhub", "username": "user", "access_token": "token"},
+		},
+		{
+			name:   "dockerhub with docker config",
+			config: map[string]any{"type": "dockerhub", "docker_config_json": `{"auths":{}}`},
+		},
+		{
+			name:   "dockerhub with write-only access token",
+			config: map[string]any{"type": "dockerhub", "username": "user", "access_token_wo": "token"},
+		},
+		{
+			name:       "dockerhub without access token",
+			config:     map[string]any{"type": "dockerhub", "username": "user"},
+			wantErrors: []string{"Missing Required Fields"},
+		},
+		{
+			name:       "ghcr with docker config",
+			config:     map[string]any{"type": "ghcr", "username": "user", "access_token": "token", "docker_config_json": `{"auths":{}}`},
+			wantErrors: []string{"Invalid Field for Registry Type"},
+		},
+		{
+			name:   "gcr with write-only service account key",
+			config: map[string]any{"type": "gcr", "service_account_key_wo": `{"type":"service_account"}`},
+		},
+		{
+			name:       "gcr with username and without service account key",
+			config:     map[string]any{"type": "gcr", "username": "user"},
+			wantErrors: []string{"Invalid Field for Registry Type", "Missing Required Fields"},
+		},
+		{
+			name:   "ecr with all fields",
+			config: map[string]any{"type": "ecr", "access_key_id": "AKIA", "secret_access_key": "secret", "region": "eu-west-1", "ecr_repo": "123.dkr.ecr.eu-west-1.amazonaws.com/app"},
+		},
+		{
+			name:       "ecr without region",
+			config:     map[string]any{"type": "ecr", "access_key_id": "AKIA", "secret_access_key_wo": "secret", "ecr_repo": "123.dkr.ecr.eu-west-1.amazonaws.com/app"},
+			wantErrors: []string{"Missing Required Fields"},
+		},
+		{
+			name:   "scaleway with all fields",
+			config: map[string]any{"type": "scaleway", "access_token": "token", "scaleway_domain": "rg.fr-par.scw.cloud", "scaleway_uuid": "uuid"},
+		},
+		{
+			name:   "custom with unknown docker config",
+			config: map[string]any{"type": "custom", "docker_config_json": unknown},
+		},
+		{
+			name:   "unknown registry type is left to the type validator",
+			config: map[string]any{"type": "quay", "username": "user"},
+		},
+		{
+			name:   "unknown type value",
+			config: map[string]any{"type": unknown},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Build the configuration through a state, which can set values by path
+			state := tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}
+			for name, value := range tt.config {
+				diags := state.SetAttribute(ctx, path.Root(name), value)
+				if diags.HasError() {
+					t.Fatalf("SetAttribute(%s) diagnostics = %v", name, diags)
+				}
+			}
+
+			req := resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: state.Schema, Raw: state.Raw}}
+			var resp resource.ValidateConfigResponse
+			registryCredentialsValidator{}.ValidateResource(ctx, req, &resp)
+
+			var gotErrors []string
+			for _, d := range resp.Diagnostics.Errors() {
+				gotErrors = append(gotErrors, d.Summary())
+			}
+			if !slices.Equal(gotErrors, tt.wantErrors) {
+				t.Errorf("errors = %v, want %v", gotErrors, tt.wantErrors)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ package integration
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -105,6 +106,25 @@ func runTerraform(t *testing.T, dir string, args ...string) string {
 	return stdout.String()
 }
 
+// outputValue returns the value of a single output from `output -json` as a string
+func outputValue(t *testing.T, output string, name string) string {
+	t.Helper()
+
+	var outputs map[string]struct {
+		Value interface{} `json:"value"`
+	}
+	if err := json.Unmarshal([]byte(output), &outputs); err != nil {
+		t.Fatalf("Failed to parse outputs: %v", err)
+	}
+
+	value, ok := outputs[name]
+	if !ok {
+		t.Fatalf("Expected %s in output", name)
+	}
+
+	return fmt.Sprint(value.Value)
+}
+
 // cleanupTestDir removes the temp directory and runs terraform destroy
 func cleanupTestDir(t *testing.T, dir string) {
 	t.Helper()
@@ -237,10 +257,71 @@ func TestVolumeResource(t *testing.T) {
 	if !strings.Contains(output, "volume_status") {
 		t.Error("Expected volume_status in output")
 	}
+	volumeID := outputValue(t, output, "volume_id")
+
+	// The clone is a new volume, grown to the configured size
+	if got := outputValue(t, output, "clone_id"); got == volumeID {
+		t.Errorf("Expected clone to be a new volume, got source volume %s", got)
+	}
+	if got := outputValue(t, output, "clone_size"); got != "120" {
+		t.Errorf("Expected clone size 120, got %s", got)
+	}
+
+	// Rename and grow the volume, which must happen in place
+	runTerraform(t, workDir, "apply", "-auto-approve", "-var", "volume_name=integration-test-volume-renamed", "-var", "volume_size=150")
+
+	output = runTerraform(t, workDir, "output", "-json")
+	if got := outputValue(t, output, "volume_id"); got != volumeID {
+		t.Errorf("Expected volume %s to be updated in place, got new volume %s", volumeID, got)
+	}
+	if got := outputValue(t, output, "volume_name"); got != "integration-test-volume-renamed" {
+		t.Errorf("Expected renamed volume, got name %s", got)
+	}
+	if got := outputValue(t, output, "volume_size"); got != "150" {
+		t.Errorf("Expected volume size 150, got %s", got)
+	}
 
 	t.Log("Volume resource test passed")
 }
 
+// TestVolumeRestoreResource tests restoring a destroyed volume from the trash
+func TestVolumeRestoreResource(t *testing.T) {
+	checkEnvVars(t)
+
+	workDir := setupTestDir(t, "volume_restore")
+	registerCleanup(t, workDir)
+
+	// Init
+	runTerraform(t, workDir, "init")
+
+	// Apply
+	runTerraform(t, workDir, "apply", "-auto-approve")
+
+	output := runTerraform(t, workDir, "output", "-json")
+	volumeID := outputValue(t, output, "volume_id")
+
+	// Destroy the volume, which moves it to the trash
+	runTerraform(t, workDir, "apply", "-auto-approve", "-var", "keep_volume=false")
+
+	// Restore it from the trash
+	restoreVars := []string{"-var", "keep_volume=false", "-var", "restore_volume_id=" + volumeID}
+	runTerraform(t, workDir, append([]string{"apply", "-auto-approve"}, restoreVars...)...)
+
+	output = runTerraform(t, workDir, "output", "-json")
+	if got := outputValue(t, output, "restored_volume_name"); got != "integration-test-volume-restore" {
+		t.Errorf("Expected restored volume integration-test-volume-restore, got %s", got)
+	}
+	if got := outputValue(t, output, "restored_volume_status"); got == "deleted" || got == "restoring" {
+		t.Errorf("Expected volume to have left the trash, got status %s", got)
+	}
+
+	// Hand the restored volume back to verda_volume so the cleanup destroys it
+	runTerraform(t, workDir, "import", "-var", "keep_volume=true", "-var", "restore_volume_id="+volumeID, "verda_volume.test[0]", volumeID)
+	runTerraform(t, workDir, "state", "rm", "verda_volume_restore.test[0]")
+
+	t.Log("Volume restore resource test passed")
+}
+
 // TestContainerRegistryCredentialsResource tests the registry credentials resource
 // Note: This resource is still in testing stage and skipped by default
 func TestContainerRegistryCredentialsResource(t *testing.T) {
@@ -272,6 +353,34 @@ func TestContainerRegistryCredentialsResource(t *testing.T) {
 	t.Log("Container Registry Credentials resource test passed")
 }
 
+// TestContainerSecretResource tests the container secret and file secret resources
+func TestContainerSecretResource(t *testing.T) {
+	checkEnvVars(t)
+
+	workDir := setupTestDir(t, "container_secret")
+	registerCleanup(t, workDir)
+
+	// Init
+	runTerraform(t, workDir, "init")
+
+	// Apply
+	runTerraform(t, workDir, "apply", "-auto-approve")
+
+	// Verify outputs
+	output := runTerraform(t, workDir, "output", "-json")
+	if got := outputValue(t, output, "secret_name"); got != "integration-test-secret" {
+		t.Errorf("Expected secret name integration-test-secret, got %s", got)
+	}
+	if !strings.Contains(output, "secret_created_at") {
+		t.Error("Expected secret_created_at in output")
+	}
+	if !strings.Contains(output, "config.json") {
+		t.Error("Expected config.json in file_secret_file_names output")
+	}
+
+	t.Log("Container Secret resource test passed")
+}
+
 // TestInstanceResource tests the instance resource following documentation examples
 // Note: This test creates real GPU instances and may incur costs
 // The test waits up to 5 minutes for the instance to be fully deployed
@@ -301,45 +410,47 @@ func TestInstanceResource(t *testing.T) {
 		t.Error("Expected instance_type in output")
 	}
 
-	// Wait for instance to be ready (up to 5 minutes, check every minute)
-	t.Log("Waiting for instance to be ready (up to 5 minutes)...")
-	instanceReady := false
-	maxAttempts := 5
-	for attempt := 1; attempt <= maxAttempts; attempt++ {
-		// Refresh state to get latest status
-		runTerraform(t, workDir, "refresh")
-		output = runTerraform(t, workDir, "output", "-json")
-
-		// Check if instance is running
-		if strings.Contains(output, `"value": "running"`) {
-			t.Logf("Instance is running after %d minute(s)", attempt)
-			instanceReady = true
-			break
-		}
-
-		if attempt < maxAttempts {
-			t.Logf("Attempt %d/%d: Instance not ready yet, waiting 1 minute...", attempt, maxAttempts)
-			// Sleep for 1 minute using time.Sleep
-			sleepCmd := exec.Command("sleep", "60")
-			_ = sleepCmd.Run() // Ignore error for sleep
-		}
+	// Create waits for the instance to be running, so status and IP are final after apply
+	if !strings.Contains(output, `"value": "running"`) {
+		t.Error("Expected instance_status to be running after apply")
+	}
+	if !strings.Contains(output, "instance_ip") {
+		t.Error("Expected instance_ip in output when instance is running")
 	}
 
-	// Final verification
-	output = runTerraform(t, workDir, "output", "-json")
-	if !strings.Contains(output, "instance_status") {
-		t.Error("Expected instance_status in output")
+	t.Log("Instance resource test passed - instance is running with IP assigned")
+}
+
+// TestVolumeAttachmentResource tests attaching a standalone volume to an instance
+// Note: This test creates a real GPU instance and may incur costs
+func TestVolumeAttachmentResource(t *testing.T) {
+	checkEnvVars(t)
+
+	// Skip if explicitly disabled (instances are expensive)
+	if os.Getenv("SKIP_INSTANCE_TEST") != "" {
+		t.Skip("Skipping volume attachment test: SKIP_INSTANCE_TEST is set")
 	}
 
-	if instanceReady {
-		// Verify IP is assigned when running
-		if !strings.Contains(output, "instance_ip") {
-			t.Error("Expected instance_ip in output when instance is running")
-		}
-		t.Log("Instance resource test passed - instance is running with IP assigned")
-	} else {
-		t.Log("Instance resource test passed - instance created but still provisioning (this is acceptable)")
+	workDir := setupTestDir(t, "volume_attachment")
+	registerCleanup(t, workDir)
+
+	// Init
+	runTerraform(t, workDir, "init")
+
+	// Apply
+	runTerraform(t, workDir, "apply", "-auto-approve")
+
+	// Create waits for the volume to be attached, so the instance is final after apply
+	output := runTerraform(t, workDir, "output", "-json")
+	instanceID := outputValue(t, output, "instance_id")
+	if got := outputValue(t, output, "attachment_instance_id"); got != instanceID {
+		t.Errorf("Expected volume to be attached to instance %s, got %s", instanceID, got)
+	}
+	if !strings.Contains(output, "attachment_status") {
+		t.Error("Expected attachment_status in output")
 	}
+
+	t.Log("Volume attachment resource test passed")
 }
 
 // TestContainerResource tests the container resource following documentation examples
@@ -402,6 +513,106 @@ func TestServerlessJobResource(t *testing.T) {
 	t.Log("Serverless Job resource test passed")
 }
 
+// TestInstanceTypesDataSource tests the instance types data source following documentation examples
+func TestInstanceTypesDataSource(t *testing.T) {
+	checkEnvVars(t)
+
+	workDir := setupTestDir(t, "instance_types")
+	registerCleanup(t, workDir)
+
+	// Init
+	runTerraform(t, workDir, "init")
+
+	// Apply
+	runTerraform(t, workDir, "apply", "-auto-approve")
+
+	// Verify outputs exist
+	output := runTerraform(t, workDir, "output", "-json")
+	if !strings.Contains(output, "instance_type_count") {
+		t.Error("Expected instance_type_count in output")
+	}
+	if !strings.Contains(output, "instance_type_names") {
+		t.Error("Expected instance_type_names in output")
+	}
+
+	t.Log("Instance Types data source test passed")
+}
+
+// TestImagesDataSource tests the images data sources following documentation examples
+func TestImagesDataSource(t *testing.T) {
+	checkEnvVars(t)
+
+	workDir := setupTestDir(t, "images")
+	registerCleanup(t, workDir)
+
+	// Init
+	runTerraform(t, workDir, "init")
+
+	// Apply
+	runTerraform(t, workDir, "apply", "-auto-approve")
+
+	// Verify outputs exist
+	output := runTerraform(t, workDir, "output", "-json")
+	if !strings.Contains(output, "image_count") {
+		t.Error("Expected image_count in output")
+	}
+	if !strings.Contains(output, "latest_ubuntu_image") {
+		t.Error("Expected latest_ubuntu_image in output")
+	}
+
+	t.Log("Images data source test passed")
+}
+
+// TestLocationsDataSource tests the locations data source following documentation examples
+func TestLocationsDataSource(t *testing.T) {
+	checkEnvVars(t)
+
+	workDir := setupTestDir(t, "locations")
+	registerCleanup(t, workDir)
+
+	// Init
+	runTerraform(t, workDir, "init")
+
+	// Apply
+	runTerraform(t, workDir, "apply", "-auto-approve")
+
+	// Verify outputs exist
+	output := runTerraform(t, workDir, "output", "-json")
+	if !strings.Contains(output, "location_codes") {
+		t.Error("Expected location_codes in output")
+	}
+	if !strings.Contains(output, "FIN-01") {
+		t.Error("Expected FIN-01 in location_codes output")
+	}
+
+	t.Log("Locations data source test passed")
+}
+
+// TestInstanceAvailabilityDataSource tests the instance availability data source following documentation examples
+func TestInstanceAvailabilityDataSource(t *testing.T) {
+	checkEnvVars(t)
+
+	workDir := setupTestDir(t, "instance_availability")
+	registerCleanup(t, workDir)
+
+	// Init
+	runTerraform(t, workDir, "init")
+
+	// Apply
+	runTerraform(t, workDir, "apply", "-auto-approve")
+
+	// Verify outputs exist
+	output := runTerraform(t, workDir, "output", "-json")
+	if !strings.Contains(output, "availability_count") {
+		t.Error("Expected availability_count in output")
+	}
+	if !strings.Contains(output, "any_available") {
+		t.Error("Expected any_available in output")
+	}
+
+	t.Log("Instance Availability data source test passed")
+}
+
 // TestAllResources runs all resource tests in sequence
 // This is useful for CI/CD pipelines
 func TestAllResources(t *testing.T) {
@@ -412,10 +623,17 @@ func TestAllResources(t *testing.T) {
 	t.Run("SSHKey", TestSSHKeyResource)
 	t.Run("StartupScript", TestStartupScriptResource)
 	t.Run("Volume", TestVolumeResource)
+	t.Run("VolumeRestore", TestVolumeRestoreResource)
 	t.Run("ContainerRegistryCredentials", TestContainerRegistryCredentialsResource)
+	t.Run("ContainerSecret", TestContainerSecretResource)
 	t.Run("Instance", TestInstanceResource)
+	t.Run("VolumeAttachment", TestVolumeAttachmentResource)
 	t.Run("Container", TestContainerResource)
 	t.Run("ServerlessJob", TestServerlessJobResource)
+	t.Run("InstanceTypes", TestInstanceTypesDataSource)
+	t.Run("Images", TestImagesDataSource)
+	t.Run("Locations", TestLocationsDataSource)
+	t.Run("InstanceAvailability", TestInstanceAvailabilityDataSource)
 }
 
 // ExampleUsage demonstrates how to run the tests
@@ -0,0 +1,32 @@
+# Integration test: Container Secret and File Secret resources
+# This test follows the documentation examples exactly
+
+resource "verda_container_secret" "test" {
+  name  = "integration-test-secret"
+  value = "integration-test-value"
+}
+
+resource "verda_container_file_secret" "test" {
+  name = "integration-test-file-secret"
+  files = {
+    "config.json" = jsonencode({ environment = "integration-test" })
+  }
+}
+
+# Output secret information for verification
+# Note: These resources use 'name' as the identifier (no 'id' attribute)
+output "secret_name" {
+  value = verda_container_secret.test.name
+}
+
+output "secret_created_at" {
+  value = verda_container_secret.test.created_at
+}
+
+output "file_secret_name" {
+  value = verda_container_file_secret.test.name
+}
+
+output "file_secret_file_names" {
+  value = verda_container_file_secret.test.file_names
+}
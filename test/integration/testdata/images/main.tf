@@ -0,0 +1,18 @@
+# Integration test: Images data sources
+# This test follows the documentation examples exactly
+
+data "verda_images" "test" {}
+
+data "verda_image" "ubuntu" {
+  name_regex  = "(?i)ubuntu"
+  most_recent = true
+}
+
+# Output image information for verification
+output "image_count" {
+  value = length(data.verda_images.test.images)
+}
+
+output "latest_ubuntu_image" {
+  value = data.verda_image.ubuntu.image_type
+}
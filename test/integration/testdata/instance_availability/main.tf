@@ -0,0 +1,16 @@
+# Integration test: Instance availability data source
+# This test follows the documentation examples exactly
+
+data "verda_instance_availability" "test" {
+  location = "FIN-01"
+  is_spot  = false
+}
+
+# Output availability information for verification
+output "availability_count" {
+  value = length(data.verda_instance_availability.test.availabilities)
+}
+
+output "any_available" {
+  value = data.verda_instance_availability.test.available
+}
@@ -0,0 +1,17 @@
+# Integration test: Instance types data source
+# This test follows the documentation examples exactly
+
+data "verda_instance_types" "test" {}
+
+data "verda_instance_types" "filtered" {
+  min_gpu_count = 1
+}
+
+# Output instance type information for verification
+output "instance_type_count" {
+  value = length(data.verda_instance_types.test.instance_types)
+}
+
+output "instance_type_names" {
+  value = data.verda_instance_types.filtered.instance_types[*].instance_type
+}
@@ -0,0 +1,9 @@
+# Integration test: Locations data source
+# This test follows the documentation examples exactly
+
+data "verda_locations" "test" {}
+
+# Output location information for verification
+output "location_codes" {
+  value = data.verda_locations.test.locations[*].code
+}
@@ -1,13 +1,33 @@
 # Integration test: Volume resource
 # This test follows the documentation examples exactly
+# Name and size are variables so the test can rename and grow the volume in place
+
+variable "volume_name" {
+  type    = string
+  default = "integration-test-volume"
+}
+
+variable "volume_size" {
+  type    = number
+  default = 100
+}
 
 resource "verda_volume" "test" {
-  name     = "integration-test-volume"
-  size     = 100 # GB
+  name     = var.volume_name
+  size     = var.volume_size # GB
   type     = "NVMe"
   location = "FIN-01"
 }
 
+# Clone the volume, growing the copy beyond the source size
+resource "verda_volume" "clone" {
+  name             = "integration-test-volume-clone"
+  size             = 120 # GB
+  type             = "NVMe"
+  location         = "FIN-01"
+  source_volume_id = verda_volume.test.id
+}
+
 # Output volume information for verification
 output "volume_id" {
   value = verda_volume.test.id
@@ -20,3 +40,15 @@ output "volume_status" {
 output "volume_name" {
   value = verda_volume.test.name
 }
+
+output "volume_size" {
+  value = verda_volume.test.size
+}
+
+output "clone_id" {
+  value = verda_volume.clone.id
+}
+
+output "clone_size" {
+  value = verda_volume.clone.size
+}
@@ -0,0 +1,45 @@
+# Integration test: Volume attachment resource
+# Attaches a standalone volume to a shut down instance
+# Instance type, image, and location are configurable via TF_VAR_* environment variables
+
+resource "verda_ssh_key" "test" {
+  name       = "volume-attachment-test-key"
+  public_key = "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABgQC7vbqajDRfEpwA9VjHSHBQ3H7pZ5TxGkYYxBN3dZwVS5yLK5sxKCxkXc2pZP5cCzRm1LqHRBMDpBYZJqvC9Wvp4sbKe0n9dHIzF7Cr9kpLPQPz6jjqKLrWfU7gZWy9LrCJPYALO1Yf4ZDEz2Z0dLKz5bXGhYa1Z3E8dJPLZvJV5SH5NtYJF7gN7w7F3hV7cLuVHU5Dh0Z5qK1mYj9Z3GhE5nT2YHkM7F5vJV5SH5NtYJF7gN7w7F3hV7cLuVHU5Dh0Z5qK1mYj9Z3GhE5nT volume-attachment-test@verda.cloud"
+}
+
+# Volumes can only be attached to shut down instances
+resource "verda_instance" "test" {
+  instance_type  = var.instance_type
+  image          = var.instance_image
+  hostname       = "integration-test-volume-attachment"
+  description    = "Integration test volume attachment instance"
+  location       = var.instance_location
+  desired_status = "shutdown"
+
+  ssh_key_ids = [verda_ssh_key.test.id]
+}
+
+resource "verda_volume" "test" {
+  name     = "integration-test-attached-volume"
+  size     = 50
+  type     = "NVMe"
+  location = var.instance_location
+}
+
+resource "verda_volume_attachment" "test" {
+  volume_id   = verda_volume.test.id
+  instance_id = verda_instance.test.id
+}
+
+# Output attachment information for verification
+output "instance_id" {
+  value = verda_instance.test.id
+}
+
+output "attachment_instance_id" {
+  value = verda_volume_attachment.test.instance_id
+}
+
+output "attachment_status" {
+  value = verda_volume_attachment.test.status
+}
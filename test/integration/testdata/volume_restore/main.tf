@@ -0,0 +1,41 @@
+# Integration test: Volume restore resource
+# The test destroys the volume by setting keep_volume to false, which moves it to the trash,
+# then restores it by setting restore_volume_id to the ID of the deleted volume
+
+variable "keep_volume" {
+  type    = bool
+  default = true
+}
+
+variable "restore_volume_id" {
+  type    = string
+  default = ""
+}
+
+resource "verda_volume" "test" {
+  count = var.keep_volume ? 1 : 0
+
+  name     = "integration-test-volume-restore"
+  size     = 50 # GB
+  type     = "NVMe"
+  location = "FIN-01"
+}
+
+resource "verda_volume_restore" "test" {
+  count = var.restore_volume_id != "" ? 1 : 0
+
+  volume_id = var.restore_volume_id
+}
+
+# Output volume information for verification
+output "volume_id" {
+  value = one(verda_volume.test[*].id)
+}
+
+output "restored_volume_name" {
+  value = one(verda_volume_restore.test[*].name)
+}
+
+output "restored_volume_status" {
+  value = one(verda_volume_restore.test[*].status)
+}